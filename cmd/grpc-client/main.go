@@ -3,16 +3,24 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
-	"log"
+	"flag"
+	"log/slog"
 	"net"
+	"os"
 	"time"
+	"unicode"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	authV1 "github.com/based-chat/auth/pkg/auth/v1"
 	srv "github.com/based-chat/auth/pkg/user/v1"
 )
 
@@ -24,12 +32,33 @@ const (
 )
 
 var (
-	errFailedConnect         = errors.New("failed to connect: %v")
-	errFailedCloseConnection = "failed to close connection: %v"
-	errFailedGetUser         = "failed to get user: %v"
-	errFailedCreateUser      = "failed to create user: %v"
-	errFailedUpdateUser      = "failed to update user: %v"
-	errFailedDeleteUser      = "failed to delete user: %v"
+	tlsCAFile     string
+	tlsCertFile   string
+	tlsKeyFile    string
+	tlsServerName string
+)
+
+// init регистрирует флаги TLS. Без `--tls-ca` клиент подключается без шифрования;
+// `--tls-cert` и `--tls-key` задают клиентский сертификат для mTLS.
+func init() {
+	flag.StringVar(&tlsCAFile, "tls-ca", "", "CA certificate used to verify the server")
+	flag.StringVar(&tlsCertFile, "tls-cert", "", "client certificate for mutual TLS")
+	flag.StringVar(&tlsKeyFile, "tls-key", "", "client private key for mutual TLS")
+	flag.StringVar(&tlsServerName, "tls-server-name", "", "expected server name, defaults to the host")
+}
+
+var (
+	errNoCACertificates = errors.New("no certificates found in ca file")
+)
+
+var (
+	errFailedConnect         = errors.New("failed to connect")
+	errFailedCloseConnection = errors.New("failed to close connection")
+	errFailedGetUser         = errors.New("failed to get user")
+	errFailedCreateUser      = errors.New("failed to create user")
+	errFailedLogin           = errors.New("failed to login")
+	errFailedUpdateUser      = errors.New("failed to update user")
+	errFailedDeleteUser      = errors.New("failed to delete user")
 )
 
 // main provides an example of how to use the grpc client to interact with the
@@ -37,52 +66,81 @@ var (
 //
 // It first creates a connection to the grpc server, then creates a user with a
 // randomly generated name, email, and password. It then gets the user by their
-// id, updates the user's name and email, and finally deletes the user.
+// id, updates the user's name and email, and finally deletes the user. All calls
+// after creation are authenticated with the access token obtained from AuthV1.Login.
 func main() {
+	flag.Parse()
+
 	addr := net.JoinHostPort(grpcHost, grpcPort)
 
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds, err := transportCredentials()
 	if err != nil {
-		log.Default().Printf(errFailedConnect.Error(), err)
+		slog.Error(errFailedConnect.Error(), slog.Any("error", err))
+		return
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		slog.Error(errFailedConnect.Error(), slog.Any("error", err))
 		return
 	}
 
 	defer func() {
 		connErr := conn.Close()
 		if connErr != nil {
-			log.Default().Printf(errFailedCloseConnection, connErr)
+			slog.Error(errFailedCloseConnection.Error(), slog.Any("error", connErr))
 		}
 	}()
 
 	c := srv.NewUserV1Client(conn)
+	a := authV1.NewAuthV1Client(conn)
+
+	email := gofakeit.Email()
+	password := strongPassword()
 
 	ctxCreateUser, cancelCreateUser := context.WithTimeout(context.Background(), maxTimeout)
 
 	createResponse, err := c.Create(ctxCreateUser, &srv.CreateRequest{
 		Name:     gofakeit.Name(),
-		Email:    gofakeit.Email(),
-		Password: gofakeit.Password(true, true, true, true, false, cntSymbolsPassword),
+		Email:    email,
+		Password: password,
 		Role:     srv.UserRole_USER,
 	})
 	if err != nil {
-		log.Default().Printf(errFailedCreateUser, err)
+		slog.Error(errFailedCreateUser.Error(), slog.Any("error", err))
 		return
 	}
 
 	cancelCreateUser()
 
-	ctxGetUser, cancelGetUser := context.WithTimeout(context.Background(), maxTimeout)
+	ctxLogin, cancelLogin := context.WithTimeout(context.Background(), maxTimeout)
+
+	loginResponse, err := a.Login(ctxLogin, &authV1.LoginRequest{
+		Email:    email,
+		Password: password,
+	})
+	if err != nil {
+		slog.Error(errFailedLogin.Error(), slog.Any("error", err))
+		return
+	}
+
+	cancelLogin()
+
+	authCtx := metadata.AppendToOutgoingContext(context.Background(),
+		"authorization", "Bearer "+loginResponse.GetTokens().GetAccessToken())
+
+	ctxGetUser, cancelGetUser := context.WithTimeout(authCtx, maxTimeout)
 
 	_, err = c.Get(ctxGetUser, &srv.GetRequest{
 		Id: createResponse.GetId(),
 	})
 	if err != nil {
-		log.Default().Printf(errFailedGetUser, err)
+		slog.Error(errFailedGetUser.Error(), slog.Any("error", err))
 	}
 
 	cancelGetUser()
 
-	ctxUpdateUser, cancelUpdateUser := context.WithTimeout(context.Background(), maxTimeout)
+	ctxUpdateUser, cancelUpdateUser := context.WithTimeout(authCtx, maxTimeout)
 
 	_, err = c.Update(ctxUpdateUser, &srv.UpdateRequest{
 		Id:    createResponse.GetId(),
@@ -90,19 +148,73 @@ func main() {
 		Email: &wrapperspb.StringValue{Value: gofakeit.Email()},
 	})
 	if err != nil {
-		log.Default().Printf(errFailedUpdateUser, err)
+		slog.Error(errFailedUpdateUser.Error(), slog.Any("error", err))
 	}
 
 	cancelUpdateUser()
 
-	ctxDeleteUser, cancelDeleteUser := context.WithTimeout(context.Background(), maxTimeout)
+	ctxDeleteUser, cancelDeleteUser := context.WithTimeout(authCtx, maxTimeout)
 
 	_, err = c.Delete(ctxDeleteUser, &srv.DeleteRequest{
 		Id: createResponse.GetId(),
 	})
 	if err != nil {
-		log.Default().Printf(errFailedDeleteUser, err)
+		slog.Error(errFailedDeleteUser.Error(), slog.Any("error", err))
 	}
 
 	cancelDeleteUser()
 }
+
+// transportCredentials возвращает учётные данные соединения по флагам TLS.
+func transportCredentials() (credentials.TransportCredentials, error) {
+	if tlsCAFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	pem, err := os.ReadFile(tlsCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, errNoCACertificates
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    roots,
+		ServerName: tlsServerName,
+	}
+
+	if tlsCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCertFile, tlsKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+// strongPassword возвращает случайный пароль, проходящий правило strong_password из user.proto:
+// в нём есть строчная и заглавная буквы и цифра.
+func strongPassword() string {
+	for {
+		password := gofakeit.Password(true, true, true, true, false, cntSymbolsPassword)
+
+		var lower, upper, digit bool
+
+		for _, r := range password {
+			lower = lower || unicode.IsLower(r)
+			upper = upper || unicode.IsUpper(r)
+			digit = digit || unicode.IsDigit(r)
+		}
+
+		if lower && upper && digit {
+			return password
+		}
+	}
+}
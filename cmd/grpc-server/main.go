@@ -5,194 +5,675 @@ import (
 	"context"
 	"errors"
 	"flag"
-	"log"
+	"fmt"
+	"io"
+	"log/slog"
 	"net"
+	"net/http"
+	"os"
 	"time"
 
+	dbMigrations "github.com/based-chat/auth/db"
+	accessAPI "github.com/based-chat/auth/internal/api/access"
+	authAPI "github.com/based-chat/auth/internal/api/auth"
+	"github.com/based-chat/auth/internal/api/dbstats"
+	"github.com/based-chat/auth/internal/api/jwks"
+	userAPI "github.com/based-chat/auth/internal/api/user"
+	"github.com/based-chat/auth/internal/app/lifecycle"
+	"github.com/based-chat/auth/internal/certs"
+	"github.com/based-chat/auth/internal/cleanup"
+	"github.com/based-chat/auth/internal/client/db"
+	"github.com/based-chat/auth/internal/client/db/pg"
 	"github.com/based-chat/auth/internal/config"
 	"github.com/based-chat/auth/internal/config/env"
-	"github.com/based-chat/auth/internal/mathx"
-	"github.com/brianvoe/gofakeit/v7"
-	"github.com/jackc/pgx/v4"
+	"github.com/based-chat/auth/internal/health"
+	"github.com/based-chat/auth/internal/interceptor"
+	"github.com/based-chat/auth/internal/keys"
+	"github.com/based-chat/auth/internal/logger"
+	"github.com/based-chat/auth/internal/metrics"
+	"github.com/based-chat/auth/internal/migrator"
+	"github.com/based-chat/auth/internal/password"
+	"github.com/based-chat/auth/internal/repository"
+	loginAttemptRepository "github.com/based-chat/auth/internal/repository/loginattempt"
+	"github.com/based-chat/auth/internal/repository/memory"
+	roleRepository "github.com/based-chat/auth/internal/repository/role"
+	tokenRepository "github.com/based-chat/auth/internal/repository/token"
+	userRepository "github.com/based-chat/auth/internal/repository/user"
+	accessService "github.com/based-chat/auth/internal/service/access"
+	authService "github.com/based-chat/auth/internal/service/auth"
+	userService "github.com/based-chat/auth/internal/service/user"
+	"github.com/based-chat/auth/internal/token"
+	"github.com/based-chat/auth/internal/tracing"
+	"github.com/jackc/pgx/v4/pgxpool"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
-	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
 
+	accessV1 "github.com/based-chat/auth/pkg/access/v1"
+	authV1 "github.com/based-chat/auth/pkg/auth/v1"
 	srv "github.com/based-chat/auth/pkg/user/v1"
 )
 
-var configPath string
+var (
+	configPath      string
+	configFile      string
+	configOverrides = make(config.Overrides)
+	migrateOnStart  bool
+	storageKind     string
+)
 
-// init инициализирует генератор случайных данных gofakeit
-// регистрирует флаг командной строки `--config-path` (по умолчанию ".env").
-// При ошибке сидирования она записывается в стандартный лог.
+// init регистрирует флаги командной строки `--config-path` (файл переменных окружения,
+// по умолчанию ".env"), `--config-file` (YAML или TOML), повторяемый `--set KEY=VALUE`,
+// `--migrate-on-start` и `--storage` (postgres или memory).
 func init() {
-	err := gofakeit.Seed(time.Now().UnixNano())
-	if err != nil {
-		log.Default().Println(errFailedSeed, err)
-	}
-
-	flag.StringVar(&configPath, "config-path", ".env", "config path")
+	flag.StringVar(&configPath, "config-path", ".env", "env file path")
+	flag.StringVar(&configFile, "config-file", "", "YAML or TOML config file path")
+	flag.Var(configOverrides, "set", "override a config value, KEY=VALUE (repeatable)")
+	flag.BoolVar(&migrateOnStart, "migrate-on-start", false, "apply pending migrations before serving")
+	flag.StringVar(&storageKind, "storage", storagePostgres, "storage backend: postgres or memory")
 }
 
 const (
-	errorIDInvalid        = "invalid ID"
-	errorNameRequired     = "name is required"
-	errorEmailRequired    = "email is required"
-	errorPasswordRequired = "password is required"
+	// storagePostgres хранит данные в PostgreSQL.
+	storagePostgres = "postgres"
+	// storageMemory хранит данные в памяти процесса; они теряются при перезапуске.
+	storageMemory = "memory"
 )
 
+const (
+	// migrateCommand — подкоманда `migrate up|down|status|redo`, которая вместо запуска сервера
+	// управляет встроенными миграциями.
+	migrateCommand = "migrate"
+	// checkConfigCommand — подкоманда, которая проверяет конфигурацию и печатает её итоговые
+	// значения со скрытыми секретами.
+	checkConfigCommand = "check-config"
+)
+
+// readHeaderTimeout ограничивает время чтения заголовков HTTP-запроса.
+const readHeaderTimeout = 5 * time.Second
+
 var (
 	errFailedListen          = errors.New("failed to listen")
 	errFailedServe           = errors.New("failed to serve")
-	errFailedSeed            = errors.New("failed to seed fakeit")
+	errFailedServeHTTP       = errors.New("failed to serve http")
+	errFailedServeMetrics    = errors.New("failed to serve metrics")
+	errFailedLoadKeys        = errors.New("failed to load signing keys")
+	errFailedLoadTLS         = errors.New("failed to load tls certificates")
 	errFailedLoadConfig      = errors.New("failed to load config")
 	errFailedConnect         = errors.New("failed to connect")
 	errFailedCloseConnection = errors.New("failed to close connection")
+	errFailedMigrate         = errors.New("failed to migrate")
+	errMigrateUsage          = errors.New("usage: migrate up|down|status|redo")
+	errUnknownStorage        = errors.New("unknown storage backend")
+	errInvalidFlags          = errors.New("invalid flags")
+	errMigrateMemoryStorage  = errors.New("--migrate-on-start requires --storage=postgres")
+	errInvalidConfig         = errors.New("invalid config")
+	errFailedInitTracing     = errors.New("failed to init tracing")
+	errFailedRun             = errors.New("server stopped with error")
 )
 
-type server struct {
-	srv.UnimplementedUserV1Server
+// storage — набор репозиториев выбранного хранилища.
+type storage struct {
+	users         repository.UserRepository
+	refreshTokens repository.RefreshTokenRepository
+	loginAttempts repository.LoginAttemptRepository
+	roles         repository.RoleRepository
+	txManager     db.TxManager
+	// pool — пул соединений с PostgreSQL; nil для хранилища в памяти.
+	pool *pgxpool.Pool
+	// migrator проверяет, что схема базы актуальна; nil для хранилища в памяти.
+	migrator *migrator.Migrator
 }
 
-// Create создает нового пользователя. В текущей реализаци его id генерируется случайным образом.
-func (s *server) Create(_ context.Context, req *srv.CreateRequest) (*srv.CreateResponse, error) {
-	if req.GetName() == "" {
-		return nil, status.Error(codes.InvalidArgument, errorNameRequired)
+// probe возвращает проверку готовности хранилища: схема PostgreSQL актуальна и база отвечает.
+// Хранилище в памяти готово всегда.
+func (s *storage) probe() health.Probe {
+	if s.pool == nil {
+		return func(context.Context) error { return nil }
 	}
 
-	if req.GetEmail() == "" {
-		return nil, status.Error(codes.InvalidArgument, errorEmailRequired)
-	}
+	return health.All(health.Migrations(s.migrator), health.Ping(s.pool))
+}
 
-	if req.GetPassword() == "" {
-		return nil, status.Error(codes.InvalidArgument, errorPasswordRequired)
+// close закрывает пул соединений и соединение мигратора.
+func (s *storage) close(context.Context) error {
+	if s.pool == nil {
+		return nil
 	}
 
-	return &srv.CreateResponse{
-		Id: mathx.Abs(gofakeit.Int64()),
-	}, nil
-}
+	s.pool.Close()
 
-// Get возвращает случайного пользователя.
-func (s *server) Get(_ context.Context, req *srv.GetRequest) (*srv.GetResponse, error) {
-	if req.GetId() <= 0 {
-		return nil, status.Error(codes.InvalidArgument, errorIDInvalid)
-	}
-	// Return a random user
-	date := gofakeit.Date()
-
-	return &srv.GetResponse{
-		Id:        req.GetId(),
-		Name:      gofakeit.Name(),
-		Email:     gofakeit.Email(),
-		Role:      srv.UserRole_USER,
-		CreatedAt: timestamppb.New(date),
-		UpdatedAt: timestamppb.New(date.Add(time.Minute)),
-	}, nil
+	return s.migrator.Close()
 }
 
-// Update обновляет пользователя.
-// В текущей реализации он возвращает случайно созданного пользователя.
-func (s *server) Update(_ context.Context, req *srv.UpdateRequest) (*srv.GetResponse, error) {
-	if req.GetId() <= 0 {
-		return nil, status.Error(codes.InvalidArgument, errorIDInvalid)
+// main запускает gRPC-сервер для сервисов UserV1, AuthV1 и AccessV1.
+//
+// Функция:
+// - собирает конфигурацию из YAML/TOML-файла, файла .env, окружения и флагов (в порядке возрастания
+// приоритета) и проверяет все её секции разом;
+// - при подкоманде `check-config` печатает итоговую конфигурацию и завершается;
+// - при подкоманде `migrate up|down|status|redo` выполняет её над встроенными миграциями и завершается,
+// а с флагом --migrate-on-start применяет миграции перед запуском;
+// - загружает или генерирует ключи подписи токенов и следит за файлами ключей и секретов,
+// перечитывая их при изменении;
+// - открывает пул соединений с PostgreSQL через pgxpool, а с флагом --storage=memory вместо этого
+// хранит данные в памяти процесса;
+// - включает TLS или mTLS, если в конфигурации заданы сертификаты;
+// - создаёт gRPC-сервер с интерцепторами аутентификации, авторизации и проверки запросов
+// по правилам validate.v1 из proto-описаний, регистрирует reflection
+// и реализации UserV1, AuthV1 и AccessV1, готовит HTTP-сервер с JWKS-документом;
+// - собирает метрики Prometheus: число и длительность gRPC-запросов, состояние пула соединений,
+// исходы входа и обмена токенов — и отдаёт их вместе со статистикой пула соединений отдельным
+// HTTP-сервером на METRICS_HOST:METRICS_PORT;
+// - трассирует вызовы UserV1, AuthV1 и AccessV1 и запросы к PostgreSQL в их рамках, продолжая
+// трассировку из метаданных запроса,
+// и отправляет спаны по OTLP либо, для локального запуска, в stdout или файл;
+// - раз в CLEANUP_INTERVAL удаляет семейства refresh-токенов, истёкшие раньше чем
+// TOKEN_REFRESH_RETENTION назад, и неудачные входы, которые вместе с блокировкой старше
+// LOGIN_LOCKOUT_DURATION;
+// - регистрирует grpc.health.v1, статус которого отражает готовность хранилища: схема актуальна
+// и база отвечает на ping;
+// - через lifecycle запускает компоненты по порядку: открывает листенеры и начинает обслуживать
+// соединения, а по SIGINT/SIGTERM или сбою одного из серверов останавливает их в обратном порядке:
+// сервисы переводятся в NOT_SERVING и выдерживается HEALTH_SHUTDOWN_DELAY, gRPC-сервер завершает
+// текущие запросы через GracefulStop, а по истечении SHUTDOWN_TIMEOUT обрывает их через Stop,
+// после чего закрываются HTTP-серверы, наблюдатель файлов и пул соединений.
+// - пишет структурированный журнал через slog в формате и с уровнем из LOG_FORMAT и LOG_LEVEL:
+// запись на каждый вызов с идентификатором запроса из x-request-id, а на уровне debug — с запросом
+// и ответом, в которых скрыты пароли, email и токены.
+// В случае ошибок загрузки конфигурации, создания листенера или установления подключения к БД функция
+// пишет ошибку в журнал и завершает процесс с кодом 1.
+func main() {
+	flag.Parse()
+
+	if migrateOnStart && storageKind == storageMemory {
+		logger.Fatal(errInvalidFlags.Error(), errMigrateMemoryStorage)
 	}
 
-	// set random name or request name
-	name := gofakeit.Name()
-	if req.GetName() != nil {
-		name = req.GetName().GetValue()
+	ctx := context.Background()
+
+	// Initialize the config
+	sources, err := config.Load(config.Options{
+		File:       configFile,
+		DotEnvFile: configPath,
+		Overrides:  configOverrides,
+	})
+	if err != nil {
+		logger.Fatal(errFailedLoadConfig.Error(), err)
 	}
 
-	// set random email or request email
-	email := gofakeit.Email()
-	if req.GetEmail() != nil {
-		email = req.GetEmail().GetValue()
+	if flag.Arg(0) == migrateCommand {
+		postgresConfig, err := env.NewPostgresConfig()
+		if err != nil {
+			logger.Fatal(errFailedLoadConfig.Error(), err)
+		}
+
+		if err = runMigrate(ctx, postgresConfig.DSN(), flag.Args()[1:]); err != nil {
+			logger.Fatal(errFailedMigrate.Error(), err)
+		}
+
+		return
 	}
 
-	// Return a random user
-	return &srv.GetResponse{
-		Id:        req.GetId(),
-		Name:      name,
-		Email:     email,
-		Role:      srv.UserRole_USER,
-		CreatedAt: timestamppb.Now(),
-		UpdatedAt: timestamppb.Now(),
-	}, nil
-}
+	cfg, err := env.NewConfig(storageKind == storagePostgres)
 
-// Delete удаляет пользователя.
-// В текущей реализации он возвращает успешное удаление.
-func (s *server) Delete(_ context.Context, req *srv.DeleteRequest) (*srv.DeleteResponse, error) {
-	if req.GetId() <= 0 {
-		return nil, status.Error(codes.InvalidArgument, errorIDInvalid)
+	if flag.Arg(0) == checkConfigCommand {
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s:\n%v\n", errInvalidConfig.Error(), err)
+			os.Exit(1)
+		}
+
+		printConfig(os.Stdout, cfg, sources)
+
+		return
 	}
-	// Return fact that user was deleted
-	return &srv.DeleteResponse{
-		Deleted: true,
-	}, nil
-}
 
-// main запускает gRPC-сервер для сервиса UserV1.
-//
-// Функция:
-// - загружает конфигурацию из файла окружения (config.Load(".env")) и формирует gRPC и Postgres конфиги;
-// - открывает TCP-листенер по адресу gRPC-конфига (gRPCConfig.Address());
-// - устанавливает подключение к PostgreSQL через pgx и откладывает его закрытие;
-// - создаёт gRPC-сервер, регистрирует reflection и реализацию UserV1, после чего начинает
-// обслуживать входящие соединения.
-// В случае ошибок загрузки конфигурации, создания листенера или установления подключения к БД функция
-// завершает процесс с логированием через log.Fatalf.
-// Ошибки во время работы s.Serve() логируются без явного завершения процесса.
-func main() {
-	flag.Parse()
+	if err != nil {
+		logger.Fatal(errInvalidConfig.Error(), err)
+	}
 
-	ctx := context.Background()
+	logger.New(cfg.Logging)
 
-	// Initialize the config
-	if err := config.Load(".env"); err != nil {
-		log.Fatalf("%s: %v", errFailedLoadConfig.Error(), err)
+	app := lifecycle.New(cfg.ShutdownTimeout)
+
+	tracerProvider, err := tracing.NewProvider(ctx, cfg.Tracing)
+	if err != nil {
+		logger.Fatal(errFailedInitTracing.Error(), err)
 	}
 
-	gRPCConfig, err := env.NewGRPCConfig()
+	// Added first, so it stops last and flushes the spans of the drained requests
+	app.Append(lifecycle.Hook{
+		Name:   "tracing",
+		OnStop: tracerProvider.Shutdown,
+	})
+
+	store, err := newStorage(ctx, storageKind, cfg.Postgres)
 	if err != nil {
-		log.Fatalf("%s: %v", errFailedLoadConfig.Error(), err)
+		logger.Fatal(errFailedConnect.Error(), err)
 	}
 
-	// Listen on the specified address
-	var lc net.ListenConfig
+	app.Append(lifecycle.Hook{
+		Name:   "storage",
+		OnStop: store.close,
+	})
+
+	passwordConfig := cfg.Password
+	tokenConfig := cfg.Token
+	userConfig := cfg.User
+	loginConfig := cfg.Login
 
-	listen, err := lc.Listen(context.Background(), "tcp", gRPCConfig.Address())
+	keySet, err := keys.LoadSet(tokenConfig.SigningKeyFile(), tokenConfig.VerificationKeyFiles())
 	if err != nil {
-		log.Fatalf("%s: %v", errFailedListen.Error(), err)
+		logger.Fatal(errFailedLoadKeys.Error(), err)
+	}
+
+	// Secrets and keys mounted from files are reloaded when the files change
+	watcher := env.NewFileWatcher(cfg.SecretReloadInterval)
+
+	for _, secret := range cfg.Secrets() {
+		watcher.Add(secret.Path(), secret.Reload)
+	}
+
+	reloadKeys := func() error {
+		next, err := keys.LoadSet(tokenConfig.SigningKeyFile(), tokenConfig.VerificationKeyFiles())
+		if err != nil {
+			return err
+		}
+
+		keySet.Update(next)
+
+		return nil
 	}
 
-	postgresConfig, err := env.NewPostgresConfig()
+	watcher.Add(tokenConfig.SigningKeyFile(), reloadKeys)
+
+	for _, path := range tokenConfig.VerificationKeyFiles() {
+		watcher.Add(path, reloadKeys)
+	}
+
+	serverOptions, err := transportOptions(cfg.GRPC, watcher)
 	if err != nil {
-		log.Fatalf("%s: %v", errFailedLoadConfig.Error(), err)
+		logger.Fatal(errFailedLoadTLS.Error(), err)
+	}
+
+	watcherCtx, stopWatcher := context.WithCancel(ctx)
+
+	app.Append(lifecycle.Hook{
+		Name: "file watcher",
+		OnStart: func(context.Context) error {
+			app.Go("file watcher", func() error {
+				watcher.Run(watcherCtx)
+				return nil
+			})
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			stopWatcher()
+			return nil
+		},
+	})
+
+	hasher := password.NewHasher(password.Params{
+		Memory:      passwordConfig.Argon2Memory(),
+		Iterations:  passwordConfig.Argon2Iterations(),
+		Parallelism: passwordConfig.Argon2Parallelism(),
+		SaltLength:  passwordConfig.Argon2SaltLength(),
+		KeyLength:   passwordConfig.Argon2KeyLength(),
+	})
+
+	tokenManager := token.NewManager(
+		tokenConfig.Issuer(),
+		tokenConfig.Audience(),
+		tokenConfig.AccessTokenTTL(),
+		tokenConfig.RefreshTokenTTL(),
+		keySet,
+	)
+
+	registry := metrics.NewRegistry()
+	grpcMetrics := metrics.NewGRPC(registry)
+
+	if store.pool != nil {
+		metrics.RegisterPool(registry, store.pool)
+	}
+
+	// Start the grpc server; request ID, tracing, access log and metrics interceptors go first
+	// to cover rejected requests too
+	authInterceptor := interceptor.NewAuth(tokenManager, interceptor.PublicMethods, interceptor.Rules)
+	tracingInterceptor := tracing.NewInterceptor(
+		srv.UserV1_ServiceDesc.ServiceName,
+		authV1.AuthV1_ServiceDesc.ServiceName,
+		accessV1.AccessV1_ServiceDesc.ServiceName,
+	)
+
+	options := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			interceptor.RequestID,
+			tracingInterceptor.Unary,
+			interceptor.AccessLog,
+			grpcMetrics.Unary,
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.RequestIDStream,
+			tracingInterceptor.Stream,
+			interceptor.AccessLogStream,
+			grpcMetrics.Stream,
+		),
+	}
+	options = append(options, serverOptions...)
+	options = append(options,
+		grpc.ChainUnaryInterceptor(authInterceptor.Unary, interceptor.Validate),
+		grpc.ChainStreamInterceptor(authInterceptor.Stream, interceptor.ValidateStream),
+	)
+
+	s := grpc.NewServer(options...)
+	reflection.Register(s)
+
+	checker := health.NewChecker(
+		[]string{
+			srv.UserV1_ServiceDesc.ServiceName,
+			authV1.AuthV1_ServiceDesc.ServiceName,
+			accessV1.AccessV1_ServiceDesc.ServiceName,
+		},
+		store.probe(),
+		cfg.Health.CheckInterval(),
+		cfg.Health.CheckTimeout(),
+	)
+	healthpb.RegisterHealthServer(s, checker.Server())
+	srv.RegisterUserV1Server(s, userAPI.NewImplementation(
+		userService.NewService(store.users, hasher, []byte(userConfig.PageTokenKey())),
+		userConfig.GetManyMaxIDs(),
+	))
+	authV1.RegisterAuthV1Server(s, authAPI.NewImplementation(
+		authService.NewService(
+			store.users,
+			store.refreshTokens,
+			store.loginAttempts,
+			hasher,
+			tokenManager,
+			store.txManager,
+			authService.Lockout{
+				MaxAttempts: loginConfig.MaxFailedAttempts(),
+				Duration:    loginConfig.LockoutDuration(),
+			},
+			metrics.NewAuth(registry),
+		),
+		keySet,
+	))
+	accessV1.RegisterAccessV1Server(s, accessAPI.NewImplementation(
+		accessService.NewService(store.users, store.roles, tokenManager),
+	))
+
+	// Start the http server with the public signing keys
+	mux := http.NewServeMux()
+	mux.Handle(jwks.Path, jwks.NewHandler(keySet))
+
+	httpServer := &http.Server{Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+
+	// Metrics and pool stats are served on their own listener so they can stay off the public network
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle(metrics.Path, metrics.Handler(registry))
+
+	if store.pool != nil {
+		metricsMux.Handle(dbstats.Path, dbstats.NewHandler(store.pool))
+	}
+
+	metricsServer := &http.Server{Handler: metricsMux, ReadHeaderTimeout: readHeaderTimeout}
+
+	checkerCtx, stopChecker := context.WithCancel(ctx)
+
+	app.Append(lifecycle.Hook{
+		Name: "health checker",
+		OnStart: func(context.Context) error {
+			app.Go("health checker", func() error {
+				checker.Run(checkerCtx)
+				return nil
+			})
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			stopChecker()
+			return nil
+		},
+	})
+
+	// Expired refresh token families are kept for the retention window and then deleted;
+	// failed logins are forgotten once they and the lockout are older than the lockout duration
+	cleaner := cleanup.NewRunner(cfg.CleanupInterval,
+		cleanup.Task{
+			Name: "refresh tokens",
+			Run: func(ctx context.Context) (int64, error) {
+				return store.refreshTokens.DeleteExpired(ctx, time.Now().Add(-tokenConfig.RefreshTokenRetention()))
+			},
+		},
+		cleanup.Task{
+			Name: "login attempts",
+			Run: func(ctx context.Context) (int64, error) {
+				return store.loginAttempts.DeleteExpired(ctx, time.Now().Add(-loginConfig.LockoutDuration()))
+			},
+		},
+	)
+
+	cleanerCtx, stopCleaner := context.WithCancel(ctx)
+
+	app.Append(lifecycle.Hook{
+		Name: "cleanup",
+		OnStart: func(context.Context) error {
+			app.Go("cleanup", func() error {
+				cleaner.Run(cleanerCtx)
+				return nil
+			})
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			stopCleaner()
+			return nil
+		},
+	})
+
+	var lc net.ListenConfig
+
+	app.Append(lifecycle.Hook{
+		Name: "http server",
+		OnStart: func(ctx context.Context) error {
+			listen, err := lc.Listen(ctx, "tcp", cfg.HTTP.Address())
+			if err != nil {
+				return fmt.Errorf("%w: %w", errFailedListen, err)
+			}
+
+			app.Go("http server", func() error {
+				if err := httpServer.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					return fmt.Errorf("%w: %w", errFailedServeHTTP, err)
+				}
+
+				return nil
+			})
+
+			return nil
+		},
+		OnStop: httpServer.Shutdown,
+	})
+
+	app.Append(lifecycle.Hook{
+		Name: "metrics server",
+		OnStart: func(ctx context.Context) error {
+			listen, err := lc.Listen(ctx, "tcp", cfg.Metrics.Address())
+			if err != nil {
+				return fmt.Errorf("%w: %w", errFailedListen, err)
+			}
+
+			app.Go("metrics server", func() error {
+				if err := metricsServer.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					return fmt.Errorf("%w: %w", errFailedServeMetrics, err)
+				}
+
+				return nil
+			})
+
+			return nil
+		},
+		OnStop: metricsServer.Shutdown,
+	})
+
+	app.Append(lifecycle.Hook{
+		Name: "grpc server",
+		OnStart: func(ctx context.Context) error {
+			listen, err := lc.Listen(ctx, "tcp", cfg.GRPC.Address())
+			if err != nil {
+				return fmt.Errorf("%w: %w", errFailedListen, err)
+			}
+
+			app.Go("grpc server", func() error {
+				if err := s.Serve(listen); err != nil {
+					return fmt.Errorf("%w: %w", errFailedServe, err)
+				}
+
+				return nil
+			})
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return lifecycle.GracefulStop(ctx, s)
+		},
+	})
+
+	// Added last, so it stops first: balancers see NOT_SERVING before the servers start draining
+	app.Append(lifecycle.Hook{
+		Name: "health drain",
+		OnStop: func(ctx context.Context) error {
+			return checker.Drain(ctx, cfg.Health.ShutdownDelay())
+		},
+	})
+
+	if err = app.Run(ctx); err != nil {
+		logger.Fatal(errFailedRun.Error(), err)
+	}
+}
+
+// runMigrate выполняет команду миграций из args над встроенными миграциями db/migrations.
+// Статус печатается в stdout; одновременный запуск с нескольких реплик сериализуется
+// advisory-блокировкой.
+func runMigrate(ctx context.Context, dsn string, args []string) error {
+	if len(args) != 1 {
+		return errMigrateUsage
 	}
 
-	conn, err := pgx.Connect(ctx, postgresConfig.DSN())
+	m, err := migrator.New(dsn, dbMigrations.Migrations())
 	if err != nil {
-		log.Fatalf("%s: %v", errFailedConnect.Error(), err)
+		return err
 	}
 
 	defer func() {
-		err := conn.Close(ctx)
-		if err != nil {
-			log.Printf("%s: %v", errFailedCloseConnection.Error(), err)
+		if err := m.Close(); err != nil {
+			slog.ErrorContext(ctx, errFailedCloseConnection.Error(), slog.Any("error", err))
 		}
 	}()
 
-	// Start the grpc server
-	s := grpc.NewServer()
-	reflection.Register(s)
-	srv.RegisterUserV1Server(s, &server{})
+	return m.Run(ctx, args[0], os.Stdout)
+}
+
+// newStorage создаёт репозитории хранилища kind. Для PostgreSQL открывается пул соединений
+// и, если задан флаг --migrate-on-start, применяются миграции; с хранилищем в памяти
+// этот флаг отклоняется ещё при запуске.
+func newStorage(ctx context.Context, kind string, postgresConfig config.PostgresConfig) (*storage, error) {
+	switch kind {
+	case storageMemory:
+		return &storage{
+			users:         memory.NewUserRepository(),
+			refreshTokens: memory.NewRefreshTokenRepository(),
+			loginAttempts: memory.NewLoginAttemptRepository(),
+			roles:         memory.NewRoleRepository(),
+			txManager:     memory.NewTxManager(),
+		}, nil
+	case storagePostgres:
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownStorage, kind)
+	}
+
+	m, err := migrator.New(postgresConfig.DSN(), dbMigrations.Migrations())
+	if err != nil {
+		return nil, err
+	}
+
+	if migrateOnStart {
+		if err = m.Up(ctx); err != nil {
+			_ = m.Close()
+			return nil, fmt.Errorf("%w: %w", errFailedMigrate, err)
+		}
+	}
+
+	pool, err := pg.NewPool(ctx, postgresConfig)
+	if err != nil {
+		_ = m.Close()
+		return nil, err
+	}
+
+	client := pg.NewClient(pool)
+
+	return &storage{
+		users:         userRepository.NewRepository(client),
+		refreshTokens: tokenRepository.NewRepository(client),
+		loginAttempts: loginAttemptRepository.NewRepository(client),
+		roles:         roleRepository.NewRepository(client),
+		txManager:     pg.NewTxManager(pool, postgresConfig.TxMaxRetries()),
+		pool:          pool,
+		migrator:      m,
+	}, nil
+}
 
-	if err = s.Serve(listen); err != nil {
-		log.Printf("%s: %v", errFailedServe.Error(), err)
+// printConfig печатает итоговую конфигурацию в формате .env. Для каждого параметра указывается
+// слой, из которого взято значение; параметры без пометки получили значение по умолчанию.
+func printConfig(out io.Writer, cfg *env.Config, sources config.Sources) {
+	for _, setting := range cfg.Settings() {
+		line := setting.Name + "=" + setting.Value
+		if layer := sources.Source(setting.Name); layer != "" {
+			line += " # " + string(layer)
+		}
+
+		_, _ = fmt.Fprintln(out, line)
+	}
+}
+
+// transportOptions возвращает параметры gRPC-сервера для TLS. Без сертификата сервер работает
+// без шифрования. С корневыми сертификатами клиентов включается mTLS и интерцептор, определяющий
+// сервис-клиента по сертификату и допускающий к AccessV1 только сервисы из
+// GRPC_TLS_ACCESS_PRINCIPALS, если он задан; интерцептор встаёт после интерцепторов
+// наблюдаемости и перед аутентификацией. Файлы сертификатов регистрируются в watcher и перечитываются при изменении.
+func transportOptions(cfg config.GRPCConfig, watcher *env.FileWatcher) ([]grpc.ServerOption, error) {
+	if !cfg.TLSEnabled() {
+		return nil, nil
+	}
+
+	reloader, err := certs.NewReloader(cfg.TLSCertFile(), cfg.TLSKeyFile(), cfg.TLSClientCAFile())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range reloader.Files() {
+		watcher.Add(file, reloader.Reload)
+	}
+
+	options := []grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(reloader.ServerConfig(cfg.TLSMinVersion()))),
 	}
+
+	if cfg.TLSClientCAFile() != "" {
+		services := make(map[string][]string)
+		if access := cfg.TLSAccessPrincipals(); len(access) > 0 {
+			services[accessV1.AccessV1_ServiceDesc.ServiceName] = access
+		}
+
+		principal := interceptor.NewPrincipal(cfg.TLSClientPrincipals(), services)
+
+		options = append(options,
+			grpc.ChainUnaryInterceptor(principal.Unary),
+			grpc.ChainStreamInterceptor(principal.Stream),
+		)
+	}
+
+	return options, nil
 }
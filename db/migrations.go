@@ -0,0 +1,21 @@
+// Package db содержит SQL-миграции схемы, встроенные в бинарник сервера.
+package db
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Migrations возвращает файловую систему с goose-миграциями из каталога db/migrations.
+func Migrations() fs.FS {
+	sub, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		// Каталог встроен директивой go:embed, поэтому ошибка возможна только при опечатке в пути.
+		panic(err)
+	}
+
+	return sub
+}
@@ -25,6 +25,6 @@ create table if not exists users (
 
 -- +goose Down
 -- +goose StatementBegin
-drop table if exists users
-drop table if exists user_role
--- +goose StatementEnd
\ No newline at end of file
+drop table if exists users;
+drop table if exists user_role;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+create table if not exists refresh_tokens (
+    id bigserial primary key,
+    user_id integer not null references users (id) on delete cascade,
+    token_hash text not null unique,
+    expires_at timestamptz not null,
+    created_at timestamptz not null default now(),
+    revoked_at timestamptz
+);
+
+create index if not exists refresh_tokens_user_id_idx on refresh_tokens (user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists refresh_tokens;
+-- +goose StatementEnd
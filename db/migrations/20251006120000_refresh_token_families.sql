@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+alter table refresh_tokens add column if not exists family_id text;
+
+update refresh_tokens set family_id = id::text where family_id is null;
+
+alter table refresh_tokens alter column family_id set not null;
+
+alter table refresh_tokens add column if not exists rotated_at timestamptz;
+
+create index if not exists refresh_tokens_family_id_idx on refresh_tokens (family_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists refresh_tokens_family_id_idx;
+alter table refresh_tokens drop column if exists rotated_at;
+alter table refresh_tokens drop column if exists family_id;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+create unique index if not exists user_role_name_idx on user_role (name);
+
+insert into user_role (name) values ('moderator'), ('support') on conflict (name) do nothing;
+
+create table if not exists permissions (
+    id serial primary key,
+    name text not null unique
+);
+
+create table if not exists role_permissions (
+    role_id integer not null references user_role (id) on delete cascade,
+    permission_id integer not null references permissions (id) on delete cascade,
+    primary key (role_id, permission_id)
+);
+
+insert into permissions (name) values
+    ('/user.v1.UserV1/Get'),
+    ('/user.v1.UserV1/Update')
+on conflict (name) do nothing;
+
+insert into role_permissions (role_id, permission_id)
+select r.id, p.id
+from user_role r
+cross join permissions p
+where r.name in ('user', 'moderator', 'support')
+  and p.name in ('/user.v1.UserV1/Get', '/user.v1.UserV1/Update')
+on conflict do nothing;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists role_permissions;
+drop table if exists permissions;
+delete from user_role where name in ('moderator', 'support');
+drop index if exists user_role_name_idx;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+alter table users add column if not exists created_at timestamptz not null default now();
+
+create index if not exists users_created_at_id_idx on users (created_at, id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists users_created_at_id_idx;
+alter table users drop column if exists created_at;
+-- +goose StatementEnd
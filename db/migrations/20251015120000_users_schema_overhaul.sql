@@ -0,0 +1,93 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Align user_role IDs with the proto UserRole enum (UNSPECIFIED=0, ADMIN=1, USER=2,
+-- MODERATOR=3, SUPPORT=4). IDs are shifted through a temporary range because unique
+-- constraints are checked row by row.
+alter table users drop constraint if exists users_role_fkey;
+alter table role_permissions drop constraint if exists role_permissions_role_id_fkey;
+
+update users u
+set role = 1000 + case r.name
+    when 'unspecified' then 0
+    when 'admin' then 1
+    when 'user' then 2
+    when 'moderator' then 3
+    when 'support' then 4
+end
+from user_role r
+where r.id = u.role;
+
+update role_permissions rp
+set role_id = 1000 + case r.name
+    when 'unspecified' then 0
+    when 'admin' then 1
+    when 'user' then 2
+    when 'moderator' then 3
+    when 'support' then 4
+end
+from user_role r
+where r.id = rp.role_id;
+
+update user_role
+set id = 1000 + case name
+    when 'unspecified' then 0
+    when 'admin' then 1
+    when 'user' then 2
+    when 'moderator' then 3
+    when 'support' then 4
+end;
+
+update user_role set id = id - 1000;
+update users set role = role - 1000;
+update role_permissions set role_id = role_id - 1000;
+
+-- New roles are added by migrations with explicit IDs matching the enum.
+alter table user_role alter column id drop default;
+drop sequence if exists user_role_id_seq;
+alter table user_role alter column name set not null;
+
+alter table users
+    add constraint users_role_fkey foreign key (role) references user_role (id);
+alter table role_permissions
+    add constraint role_permissions_role_id_fkey foreign key (role_id) references user_role (id) on delete cascade;
+alter table users alter column role set default 2;
+
+-- The confirmation is checked by clients and must never be persisted.
+alter table users drop column if exists password_confirmation;
+
+-- Emails are unique regardless of case.
+create unique index if not exists users_email_lower_idx on users (lower(email));
+
+alter table users add column if not exists updated_at timestamptz not null default now();
+update users set updated_at = created_at;
+
+create or replace function set_updated_at() returns trigger as $$
+begin
+    new.updated_at = now();
+    return new;
+end;
+$$ language plpgsql;
+
+create trigger users_set_updated_at
+    before update on users
+    for each row
+    execute function set_updated_at();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Role IDs stay aligned with the enum: the previous migrations only rely on role names.
+drop trigger if exists users_set_updated_at on users;
+drop function if exists set_updated_at();
+alter table users drop column if exists updated_at;
+drop index if exists users_email_lower_idx;
+alter table users add column if not exists password_confirmation text;
+update users set password_confirmation = password;
+alter table users alter column password_confirmation set not null;
+alter table users alter column role drop default;
+create sequence if not exists user_role_id_seq owned by user_role.id;
+select setval('user_role_id_seq', (select max(id) from user_role));
+alter table user_role alter column id set default nextval('user_role_id_seq');
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Неудачные входы учитываются по хешу email, а не по пользователю: так адреса без учётной
+-- записи считаются так же, как существующие, и ответы на вход их не различают.
+create table if not exists login_attempts (
+    email_hash text primary key,
+    failed_attempts integer not null default 0,
+    locked_until timestamptz
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists login_attempts;
+-- +goose StatementEnd
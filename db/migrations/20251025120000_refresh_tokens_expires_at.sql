@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Индекс по сроку истечения нужен периодической очистке истёкших семейств refresh-токенов.
+create index if not exists refresh_tokens_expires_at_idx on refresh_tokens (expires_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists refresh_tokens_expires_at_idx;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Доступ к UserV1 решают правила интерцептора авторизации, а role_permissions описывают
+-- только другие сервисы стека, поэтому выданные ранее разрешения на UserV1 ничего не значили.
+delete from permissions where name in ('/user.v1.UserV1/Get', '/user.v1.UserV1/Update');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+insert into permissions (name) values
+    ('/user.v1.UserV1/Get'),
+    ('/user.v1.UserV1/Update')
+on conflict (name) do nothing;
+
+insert into role_permissions (role_id, permission_id)
+select r.id, p.id
+from user_role r
+cross join permissions p
+where r.name in ('user', 'moderator', 'support')
+  and p.name in ('/user.v1.UserV1/Get', '/user.v1.UserV1/Update')
+on conflict do nothing;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Записи, в которых и последняя неудача, и блокировка старше срока блокировки, удаляются
+-- периодической очисткой; индекс по более позднему из этих моментов ей нужен.
+alter table login_attempts add column if not exists last_failed_at timestamptz not null default now();
+
+create index if not exists login_attempts_expires_idx
+    on login_attempts ((greatest(last_failed_at, locked_until)));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists login_attempts_expires_idx;
+alter table login_attempts drop column if exists last_failed_at;
+-- +goose StatementEnd
@@ -0,0 +1,75 @@
+// Package access implements the AccessV1 gRPC API.
+package access
+
+import (
+	"context"
+
+	"github.com/based-chat/auth/internal/apperr"
+	"github.com/based-chat/auth/internal/converter"
+	"github.com/based-chat/auth/internal/service"
+
+	srv "github.com/based-chat/auth/pkg/access/v1"
+)
+
+// Implementation — реализация gRPC-сервиса AccessV1.
+type Implementation struct {
+	srv.UnimplementedAccessV1Server
+
+	accessService service.AccessService
+}
+
+// NewImplementation создаёт обработчики AccessV1 поверх сервиса проверки доступа.
+func NewImplementation(accessService service.AccessService) *Implementation {
+	return &Implementation{accessService: accessService}
+}
+
+// Check сообщает, разрешён ли владельцу access-токена вызов эндпоинта.
+// Недействительный токен отдаётся как codes.Unauthenticated; запрет — как allowed=false.
+func (i *Implementation) Check(ctx context.Context, req *srv.CheckRequest) (*srv.CheckResponse, error) {
+	decision, err := i.accessService.Check(ctx, req.GetAccessToken(), req.GetEndpoint())
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return converter.ToCheckResponseFromDecision(decision), nil
+}
+
+// ListRoles возвращает все роли с выданными им разрешениями.
+func (i *Implementation) ListRoles(ctx context.Context, _ *srv.ListRolesRequest) (*srv.ListRolesResponse, error) {
+	roles, err := i.accessService.ListRoles(ctx)
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return converter.ToListRolesResponseFromRoles(roles), nil
+}
+
+// GrantPermission выдаёт роли разрешение.
+func (i *Implementation) GrantPermission(
+	ctx context.Context,
+	req *srv.GrantPermissionRequest,
+) (*srv.GrantPermissionResponse, error) {
+	err := i.accessService.GrantPermission(ctx, converter.ToRoleFromProto(req.GetRole()), req.GetPermission())
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return &srv.GrantPermissionResponse{
+		Granted: true,
+	}, nil
+}
+
+// RevokePermission отзывает у роли разрешение.
+func (i *Implementation) RevokePermission(
+	ctx context.Context,
+	req *srv.RevokePermissionRequest,
+) (*srv.RevokePermissionResponse, error) {
+	err := i.accessService.RevokePermission(ctx, converter.ToRoleFromProto(req.GetRole()), req.GetPermission())
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return &srv.RevokePermissionResponse{
+		Revoked: true,
+	}, nil
+}
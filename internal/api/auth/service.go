@@ -0,0 +1,70 @@
+// Package auth implements the AuthV1 gRPC API.
+package auth
+
+import (
+	"context"
+
+	"github.com/based-chat/auth/internal/apperr"
+	"github.com/based-chat/auth/internal/converter"
+	"github.com/based-chat/auth/internal/keys"
+	"github.com/based-chat/auth/internal/service"
+
+	srv "github.com/based-chat/auth/pkg/auth/v1"
+)
+
+// Implementation — реализация gRPC-сервиса AuthV1.
+type Implementation struct {
+	srv.UnimplementedAuthV1Server
+
+	authService service.AuthService
+	keySet      *keys.Set
+}
+
+// NewImplementation создаёт обработчики AuthV1 поверх сервиса аутентификации и набора ключей подписи.
+func NewImplementation(authService service.AuthService, keySet *keys.Set) *Implementation {
+	return &Implementation{
+		authService: authService,
+		keySet:      keySet,
+	}
+}
+
+// Login проверяет email и пароль и возвращает пару access/refresh токенов.
+func (i *Implementation) Login(ctx context.Context, req *srv.LoginRequest) (*srv.LoginResponse, error) {
+	user, tokens, err := i.authService.Login(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return &srv.LoginResponse{
+		UserId: user.ID,
+		Tokens: converter.ToProtoFromTokens(tokens),
+	}, nil
+}
+
+// Refresh обменивает refresh-токен на новую пару токенов.
+func (i *Implementation) Refresh(ctx context.Context, req *srv.RefreshRequest) (*srv.RefreshResponse, error) {
+	tokens, err := i.authService.Refresh(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return &srv.RefreshResponse{
+		Tokens: converter.ToProtoFromTokens(tokens),
+	}, nil
+}
+
+// Logout завершает сессию, к которой относится refresh-токен.
+func (i *Implementation) Logout(ctx context.Context, req *srv.LogoutRequest) (*srv.LogoutResponse, error) {
+	if err := i.authService.Logout(ctx, req.GetRefreshToken()); err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return &srv.LogoutResponse{
+		LoggedOut: true,
+	}, nil
+}
+
+// GetJWKS возвращает открытые ключи, которыми можно проверить выданные access-токены.
+func (i *Implementation) GetJWKS(_ context.Context, _ *srv.GetJWKSRequest) (*srv.GetJWKSResponse, error) {
+	return converter.ToGetJWKSResponseFromJWKS(i.keySet.JWKS()), nil
+}
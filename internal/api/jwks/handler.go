@@ -0,0 +1,44 @@
+// Package jwks serves the public signing keys over HTTP.
+package jwks
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/based-chat/auth/internal/keys"
+)
+
+// Path — стандартный путь JWKS-документа.
+const Path = "/.well-known/jwks.json"
+
+// cacheControl разрешает клиентам кешировать набор ключей. Срок выбран коротким, чтобы
+// проверяющие сервисы быстро узнавали о новом ключе после ротации.
+const cacheControl = "public, max-age=300"
+
+// Handler отдаёт набор открытых ключей в формате RFC 7517.
+type Handler struct {
+	keySet *keys.Set
+}
+
+// NewHandler создаёт HTTP-обработчик JWKS-документа.
+func NewHandler(keySet *keys.Set) *Handler {
+	return &Handler{keySet: keySet}
+}
+
+// ServeHTTP отвечает на GET и HEAD JSON-документом с открытыми ключами.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", cacheControl)
+
+	if err := json.NewEncoder(w).Encode(h.keySet.JWKS()); err != nil {
+		slog.ErrorContext(r.Context(), "failed to write jwks", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,122 @@
+// Package user implements the UserV1 gRPC API.
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/based-chat/auth/internal/apperr"
+	"github.com/based-chat/auth/internal/converter"
+	"github.com/based-chat/auth/internal/service"
+
+	srv "github.com/based-chat/auth/pkg/user/v1"
+)
+
+// Implementation — реализация gRPC-сервиса UserV1.
+type Implementation struct {
+	srv.UnimplementedUserV1Server
+
+	userService   service.UserService
+	getManyMaxIDs int
+}
+
+// NewImplementation создаёт обработчики UserV1 поверх сервиса пользователей.
+// getManyMaxIDs ограничивает число ID в одном запросе GetMany.
+func NewImplementation(userService service.UserService, getManyMaxIDs int) *Implementation {
+	return &Implementation{
+		userService:   userService,
+		getManyMaxIDs: getManyMaxIDs,
+	}
+}
+
+// Create создает нового пользователя и возвращает его ID.
+// Поля запроса проверяются интерцептором по правилам из user.proto.
+func (i *Implementation) Create(ctx context.Context, req *srv.CreateRequest) (*srv.CreateResponse, error) {
+	id, err := i.userService.Create(ctx, converter.ToUserFromCreateRequest(req), req.GetPassword())
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return &srv.CreateResponse{
+		Id: id,
+	}, nil
+}
+
+// Get возвращает пользователя по ID.
+func (i *Implementation) Get(ctx context.Context, req *srv.GetRequest) (*srv.GetResponse, error) {
+	user, err := i.userService.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return converter.ToGetResponseFromUser(user), nil
+}
+
+// Update обновляет переданные в запросе поля пользователя и возвращает его актуальное состояние.
+func (i *Implementation) Update(ctx context.Context, req *srv.UpdateRequest) (*srv.GetResponse, error) {
+	user, err := i.userService.Update(ctx, req.GetId(), converter.ToUserUpdateFromRequest(req))
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return converter.ToGetResponseFromUser(user), nil
+}
+
+// Delete удаляет пользователя.
+func (i *Implementation) Delete(ctx context.Context, req *srv.DeleteRequest) (*srv.DeleteResponse, error) {
+	if err := i.userService.Delete(ctx, req.GetId()); err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return &srv.DeleteResponse{
+		Deleted: true,
+	}, nil
+}
+
+// GetMany возвращает пользователей по списку ID и перечисляет ID, которых нет.
+// Наибольшее число ID задаётся конфигурацией, поэтому проверяется здесь, а не правилами user.proto.
+func (i *Implementation) GetMany(ctx context.Context, req *srv.GetManyRequest) (*srv.GetManyResponse, error) {
+	if len(req.GetIds()) > i.getManyMaxIDs {
+		return nil, apperr.InvalidArgument(apperr.ReasonInvalidArgument, apperr.FieldViolation{
+			Field:       "ids",
+			Description: fmt.Sprintf("value must contain no more than %d item(s)", i.getManyMaxIDs),
+		})
+	}
+
+	users, missing, err := i.userService.GetMany(ctx, req.GetIds())
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return converter.ToGetManyResponseFromUsers(users, missing), nil
+}
+
+// List возвращает страницу пользователей, отобранных и упорядоченных по запросу.
+func (i *Implementation) List(ctx context.Context, req *srv.ListRequest) (*srv.ListResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+
+	users, nextPageToken, err := i.userService.List(ctx, converter.ToUserListQueryFromRequest(req), req.GetPageToken())
+	if err != nil {
+		return nil, apperr.ToStatus(ctx, err)
+	}
+
+	return converter.ToListResponseFromUsers(users, nextPageToken), nil
+}
+
+// validateListRequest проверяет диапазон дат фильтра. Остальные поля проверяются
+// интерцептором по правилам из user.proto; сравнение двух полей в них не выражается.
+func validateListRequest(req *srv.ListRequest) error {
+	filter := req.GetFilter()
+
+	after, before := filter.GetCreatedAfter(), filter.GetCreatedBefore()
+	if after != nil && before != nil && !after.AsTime().Before(before.AsTime()) {
+		return apperr.InvalidArgument(apperr.ReasonInvalidArgument, apperr.FieldViolation{
+			Field:       "filter.created_after",
+			Description: "value must be before filter.created_before",
+		})
+	}
+
+	return nil
+}
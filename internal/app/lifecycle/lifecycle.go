@@ -0,0 +1,143 @@
+// Package lifecycle starts application components in order and stops them in reverse order
+// on SIGINT/SIGTERM or when one of them fails.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+var (
+	errFailedStart       = errors.New("failed to start")
+	errFailedStop        = errors.New("failed to stop")
+	errComponentFailed   = errors.New("component failed")
+	errGracefulStopTimed = errors.New("graceful stop timed out")
+)
+
+// Hook — компонент приложения. OnStart не должен блокироваться: долгую работу компонент
+// запускает через Lifecycle.Go. Любой из обработчиков может быть nil.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle управляет запуском и остановкой компонентов.
+type Lifecycle struct {
+	hooks           []Hook
+	shutdownTimeout time.Duration
+	failures        chan error
+}
+
+// New создаёт менеджер жизненного цикла. На остановку всех компонентов отводится shutdownTimeout.
+func New(shutdownTimeout time.Duration) *Lifecycle {
+	return &Lifecycle{
+		shutdownTimeout: shutdownTimeout,
+		failures:        make(chan error, 1),
+	}
+}
+
+// Append добавляет компонент. Компоненты запускаются в порядке добавления, а останавливаются в обратном.
+func (l *Lifecycle) Append(hook Hook) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// Go запускает фоновую работу компонента name, например цикл Serve сервера.
+// Ошибка run инициирует остановку приложения; nil означает штатное завершение.
+func (l *Lifecycle) Go(name string, run func() error) {
+	go func() {
+		if err := run(); err != nil {
+			select {
+			case l.failures <- fmt.Errorf("%w: %s: %w", errComponentFailed, name, err):
+			default:
+			}
+		}
+	}()
+}
+
+// Run запускает компоненты и ждёт SIGINT, SIGTERM, отмены ctx или сбоя фоновой работы, после
+// чего останавливает запущенные компоненты в обратном порядке. Если компонент не запустился,
+// уже запущенные останавливаются, а Run возвращает ошибку запуска.
+func (l *Lifecycle) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	started := 0
+
+	for _, hook := range l.hooks {
+		if hook.OnStart != nil {
+			if err := hook.OnStart(ctx); err != nil {
+				startErr := fmt.Errorf("%w: %s: %w", errFailedStart, hook.Name, err)
+
+				return errors.Join(startErr, l.stop(ctx, started))
+			}
+		}
+
+		started++
+	}
+
+	var cause error
+
+	select {
+	case <-ctx.Done():
+		slog.InfoContext(ctx, "shutting down")
+	case cause = <-l.failures:
+		slog.ErrorContext(ctx, "shutting down", slog.Any("error", cause))
+	}
+
+	return errors.Join(cause, l.stop(ctx, started))
+}
+
+// stop останавливает первые count компонентов в обратном порядке. Все компоненты делят
+// общий срок shutdownTimeout; ошибки остановки не прерывают остановку остальных.
+func (l *Lifecycle) stop(ctx context.Context, count int) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), l.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+
+	for i := count - 1; i >= 0; i-- {
+		hook := l.hooks[i]
+		if hook.OnStop == nil {
+			continue
+		}
+
+		if err := hook.OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s: %w", errFailedStop, hook.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// GracefulStopper — сервер с плавной и немедленной остановкой, как *grpc.Server.
+type GracefulStopper interface {
+	GracefulStop()
+	Stop()
+}
+
+// GracefulStop плавно останавливает сервер, дожидаясь завершения текущих запросов. Если ctx
+// истекает раньше, оставшиеся соединения закрываются принудительно через Stop.
+func GracefulStop(ctx context.Context, server GracefulStopper) error {
+	done := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		<-done
+
+		return errGracefulStopTimed
+	}
+}
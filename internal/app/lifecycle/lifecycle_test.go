@@ -0,0 +1,202 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/based-chat/auth/internal/app/lifecycle"
+)
+
+var (
+	errStart = errors.New("start failed")
+	errStop  = errors.New("stop failed")
+	errServe = errors.New("serve failed")
+)
+
+// recorder записывает события запуска и остановки компонентов.
+type recorder struct {
+	events []string
+}
+
+// hook возвращает компонент name, записывающий свои события. Ошибки startErr и stopErr
+// возвращаются из соответствующих обработчиков.
+func (r *recorder) hook(name string, startErr, stopErr error) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			r.events = append(r.events, "start "+name)
+			return startErr
+		},
+		OnStop: func(context.Context) error {
+			r.events = append(r.events, "stop "+name)
+			return stopErr
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name string
+		// build добавляет компоненты; cancel завершает работу приложения.
+		build      func(l *lifecycle.Lifecycle, r *recorder, cancel context.CancelFunc)
+		wantEvents []string
+		wantErr    []error
+	}{
+		{
+			name: "stops in reverse order",
+			build: func(l *lifecycle.Lifecycle, r *recorder, cancel context.CancelFunc) {
+				l.Append(r.hook("a", nil, nil))
+				l.Append(lifecycle.Hook{Name: "no handlers"})
+				l.Append(r.hook("b", nil, nil))
+				l.Append(lifecycle.Hook{Name: "shutdown", OnStart: func(context.Context) error {
+					cancel()
+					return nil
+				}})
+			},
+			wantEvents: []string{"start a", "start b", "stop b", "stop a"},
+		},
+		{
+			name: "failed start stops started components",
+			build: func(l *lifecycle.Lifecycle, r *recorder, _ context.CancelFunc) {
+				l.Append(r.hook("a", nil, nil))
+				l.Append(r.hook("b", errStart, nil))
+				l.Append(r.hook("c", nil, nil))
+			},
+			wantEvents: []string{"start a", "start b", "stop a"},
+			wantErr:    []error{errStart},
+		},
+		{
+			name: "failed background work stops everything",
+			build: func(l *lifecycle.Lifecycle, r *recorder, _ context.CancelFunc) {
+				l.Append(r.hook("a", nil, nil))
+				l.Append(lifecycle.Hook{Name: "server", OnStart: func(context.Context) error {
+					l.Go("server", func() error { return errServe })
+					return nil
+				}})
+				l.Append(r.hook("b", nil, nil))
+			},
+			wantEvents: []string{"start a", "start b", "stop b", "stop a"},
+			wantErr:    []error{errServe},
+		},
+		{
+			name: "failed stop does not interrupt shutdown",
+			build: func(l *lifecycle.Lifecycle, r *recorder, cancel context.CancelFunc) {
+				l.Append(r.hook("a", nil, nil))
+				l.Append(r.hook("b", nil, errStop))
+				l.Append(lifecycle.Hook{Name: "shutdown", OnStart: func(context.Context) error {
+					cancel()
+					return nil
+				}})
+			},
+			wantEvents: []string{"start a", "start b", "stop b", "stop a"},
+			wantErr:    []error{errStop},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			l := lifecycle.New(time.Second)
+			r := &recorder{}
+			tt.build(l, r, cancel)
+
+			err := l.Run(ctx)
+
+			if !slices.Equal(r.events, tt.wantEvents) {
+				t.Errorf("events = %v, want %v", r.events, tt.wantEvents)
+			}
+
+			if len(tt.wantErr) == 0 && err != nil {
+				t.Errorf("Run() error = %v, want nil", err)
+			}
+
+			for _, want := range tt.wantErr {
+				if !errors.Is(err, want) {
+					t.Errorf("Run() error = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunStopDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := lifecycle.New(time.Minute)
+
+	var stopCtxErr error
+
+	deadlineSet := false
+
+	l.Append(lifecycle.Hook{
+		Name: "component",
+		OnStart: func(context.Context) error {
+			cancel()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			_, deadlineSet = ctx.Deadline()
+			stopCtxErr = ctx.Err()
+
+			return nil
+		},
+	})
+
+	if err := l.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// Остановка получает собственный срок, а не уже отменённый контекст Run.
+	if !deadlineSet || stopCtxErr != nil {
+		t.Errorf("stop context deadline set = %v, error = %v, want a live context with a deadline", deadlineSet, stopCtxErr)
+	}
+}
+
+// server имитирует gRPC-сервер: GracefulStop ждёт, пока не завершатся запросы или не будет вызван Stop.
+type server struct {
+	drained chan struct{}
+	stopped bool
+}
+
+func (s *server) GracefulStop() {
+	<-s.drained
+}
+
+func (s *server) Stop() {
+	s.stopped = true
+	close(s.drained)
+}
+
+func TestGracefulStop(t *testing.T) {
+	t.Run("requests finish in time", func(t *testing.T) {
+		s := &server{drained: make(chan struct{})}
+		close(s.drained)
+
+		if err := lifecycle.GracefulStop(context.Background(), s); err != nil {
+			t.Errorf("GracefulStop() error = %v, want nil", err)
+		}
+
+		if s.stopped {
+			t.Error("GracefulStop() forced Stop, want a graceful stop")
+		}
+	})
+
+	t.Run("deadline forces stop", func(t *testing.T) {
+		s := &server{drained: make(chan struct{})}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := lifecycle.GracefulStop(ctx, s); err == nil {
+			t.Error("GracefulStop() error = nil, want a timeout error")
+		}
+
+		if !s.stopped {
+			t.Error("GracefulStop() did not force Stop after the deadline")
+		}
+	})
+}
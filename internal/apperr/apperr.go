@@ -0,0 +1,211 @@
+// Package apperr maps domain errors to gRPC statuses with machine-readable details.
+package apperr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/based-chat/auth/internal/repository"
+	"github.com/based-chat/auth/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Domain — домен ошибок сервиса в google.rpc.ErrorInfo.
+const Domain = "auth.based-chat"
+
+// Причины ошибок в google.rpc.ErrorInfo. Коды стабильны: клиенты ветвятся по ним,
+// а не по тексту сообщения, поэтому существующие значения не меняются.
+const (
+	ReasonInvalidArgument     = "INVALID_ARGUMENT"
+	ReasonInvalidPageToken    = "INVALID_PAGE_TOKEN"
+	ReasonUserNotFound        = "USER_NOT_FOUND"
+	ReasonEmailTaken          = "EMAIL_TAKEN"
+	ReasonRoleNotFound        = "ROLE_NOT_FOUND"
+	ReasonInvalidCredentials  = "INVALID_CREDENTIALS"
+	ReasonAccountLocked       = "ACCOUNT_LOCKED"
+	ReasonInvalidRefreshToken = "INVALID_REFRESH_TOKEN"
+	ReasonAccessTokenRequired = "ACCESS_TOKEN_REQUIRED"
+	ReasonInvalidAccessToken  = "INVALID_ACCESS_TOKEN"
+	ReasonUnknownPrincipal    = "UNKNOWN_PRINCIPAL"
+	ReasonPermissionDenied    = "PERMISSION_DENIED"
+	ReasonInternal            = "INTERNAL"
+)
+
+const (
+	errorInternal      = "internal error"
+	errorAccountLocked = "account is temporarily locked"
+
+	// metadataLockedUntil — ключ метаданных ErrorInfo с моментом снятия блокировки в RFC 3339.
+	metadataLockedUntil = "locked_until"
+)
+
+// FieldViolation — нарушение правила одним полем запроса.
+type FieldViolation struct {
+	// Field — путь к полю, например "filter.created_after".
+	Field string
+	// Description — описание нарушения.
+	Description string
+}
+
+// mapping описывает, как ошибка target отдаётся клиенту.
+type mapping struct {
+	target  error
+	code    codes.Code
+	reason  string
+	message string
+	// field — поле запроса, к которому относится ошибка; для них ответ дополняется
+	// google.rpc.BadRequest.
+	field string
+}
+
+// mappings — известные ошибки хранилища и сервисов. Повторное предъявление refresh-токена
+// намеренно неотличимо от недействительного токена.
+var mappings = []mapping{
+	{
+		target:  repository.ErrUserNotFound,
+		code:    codes.NotFound,
+		reason:  ReasonUserNotFound,
+		message: "user not found",
+	},
+	{
+		target:  repository.ErrEmailTaken,
+		code:    codes.AlreadyExists,
+		reason:  ReasonEmailTaken,
+		message: "email already taken",
+	},
+	{
+		target:  repository.ErrRoleNotFound,
+		code:    codes.NotFound,
+		reason:  ReasonRoleNotFound,
+		message: "role not found",
+	},
+	{
+		target:  service.ErrInvalidPageToken,
+		code:    codes.InvalidArgument,
+		reason:  ReasonInvalidPageToken,
+		message: "invalid page token",
+		field:   "page_token",
+	},
+	{
+		target:  service.ErrInvalidCredentials,
+		code:    codes.Unauthenticated,
+		reason:  ReasonInvalidCredentials,
+		message: "invalid email or password",
+	},
+	{
+		target:  service.ErrInvalidRefreshToken,
+		code:    codes.Unauthenticated,
+		reason:  ReasonInvalidRefreshToken,
+		message: "invalid refresh token",
+	},
+	{
+		target:  service.ErrRefreshTokenReused,
+		code:    codes.Unauthenticated,
+		reason:  ReasonInvalidRefreshToken,
+		message: "invalid refresh token",
+	},
+	{
+		target:  service.ErrInvalidAccessToken,
+		code:    codes.Unauthenticated,
+		reason:  ReasonInvalidAccessToken,
+		message: "invalid access token",
+	},
+}
+
+// ToStatus преобразует ошибку сервиса в gRPC-статус с google.rpc.ErrorInfo.
+// Блокировка учётной записи отдаётся как codes.ResourceExhausted с google.rpc.RetryInfo,
+// ошибки полей запроса дополняются google.rpc.BadRequest. Неизвестная ошибка пишется
+// в журнал и отдаётся как codes.Internal с причиной INTERNAL, но без текста исходной ошибки.
+func ToStatus(ctx context.Context, err error) error {
+	var locked *service.LockedError
+	if errors.As(err, &locked) {
+		return accountLocked(locked.Until)
+	}
+
+	for _, m := range mappings {
+		if !errors.Is(err, m.target) {
+			continue
+		}
+
+		if m.field != "" {
+			return InvalidArgument(m.reason, FieldViolation{Field: m.field, Description: m.message})
+		}
+
+		return New(m.code, m.reason, m.message)
+	}
+
+	slog.ErrorContext(ctx, errorInternal, slog.Any("error", err))
+
+	return New(codes.Internal, ReasonInternal, codes.Internal.String())
+}
+
+// New возвращает gRPC-ошибку с кодом code, сообщением message и причиной reason в ErrorInfo.
+func New(code codes.Code, reason, message string) error {
+	return withDetails(status.New(code, message), errorInfo(reason, nil))
+}
+
+// InvalidArgument возвращает ошибку codes.InvalidArgument с причиной reason и нарушениями
+// violations в google.rpc.BadRequest. Сообщение перечисляет нарушения через "; ".
+func InvalidArgument(reason string, violations ...FieldViolation) error {
+	descriptions := make([]string, len(violations))
+	badRequest := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(violations)),
+	}
+
+	for i, violation := range violations {
+		descriptions[i] = violation.Field + ": " + violation.Description
+		badRequest.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		}
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(descriptions, "; "))
+
+	return withDetails(st, errorInfo(reason, nil), badRequest)
+}
+
+// accountLocked возвращает ошибку блокировки учётной записи до момента until.
+// RetryInfo сообщает, через сколько секунд вход снова станет возможен.
+func accountLocked(until time.Time) error {
+	retryDelay := time.Until(until)
+	if rounded := retryDelay.Truncate(time.Second); rounded < retryDelay {
+		retryDelay = rounded + time.Second
+	}
+
+	info := errorInfo(ReasonAccountLocked, map[string]string{
+		metadataLockedUntil: until.UTC().Format(time.RFC3339),
+	})
+
+	return withDetails(
+		status.New(codes.ResourceExhausted, errorAccountLocked),
+		info,
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)},
+	)
+}
+
+// errorInfo создаёт google.rpc.ErrorInfo домена сервиса.
+func errorInfo(reason string, metadata map[string]string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   Domain,
+		Metadata: metadata,
+	}
+}
+
+// withDetails добавляет к st подробности details. Если их не удалось сериализовать,
+// возвращается статус без подробностей: код и сообщение клиент получит в любом случае.
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	if detailed, err := st.WithDetails(details...); err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,224 @@
+package apperr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/based-chat/auth/internal/apperr"
+	"github.com/based-chat/auth/internal/repository"
+	"github.com/based-chat/auth/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// details раскладывает подробности статуса по типам.
+type details struct {
+	info       *errdetails.ErrorInfo
+	badRequest *errdetails.BadRequest
+	retry      *errdetails.RetryInfo
+}
+
+// statusOf возвращает статус ошибки err и её подробности.
+func statusOf(t *testing.T, err error) (*status.Status, details) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("error %v is not a gRPC status", err)
+	}
+
+	var d details
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			d.info = detail
+		case *errdetails.BadRequest:
+			d.badRequest = detail
+		case *errdetails.RetryInfo:
+			d.retry = detail
+		default:
+			t.Errorf("unexpected detail %T", detail)
+		}
+	}
+
+	if d.info == nil {
+		t.Fatal("status has no ErrorInfo")
+	}
+
+	if d.info.GetDomain() != apperr.Domain {
+		t.Errorf("ErrorInfo domain = %q, want %q", d.info.GetDomain(), apperr.Domain)
+	}
+
+	return st, d
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantReason  string
+		wantMessage string
+		wantField   string
+	}{
+		{
+			name:        "user not found",
+			err:         repository.ErrUserNotFound,
+			wantCode:    codes.NotFound,
+			wantReason:  apperr.ReasonUserNotFound,
+			wantMessage: "user not found",
+		},
+		{
+			name:        "wrapped email taken",
+			err:         fmt.Errorf("create user: %w", repository.ErrEmailTaken),
+			wantCode:    codes.AlreadyExists,
+			wantReason:  apperr.ReasonEmailTaken,
+			wantMessage: "email already taken",
+		},
+		{
+			name:        "role not found",
+			err:         repository.ErrRoleNotFound,
+			wantCode:    codes.NotFound,
+			wantReason:  apperr.ReasonRoleNotFound,
+			wantMessage: "role not found",
+		},
+		{
+			name:        "invalid page token",
+			err:         fmt.Errorf("%w: %w", service.ErrInvalidPageToken, errors.New("illegal base64 data")),
+			wantCode:    codes.InvalidArgument,
+			wantReason:  apperr.ReasonInvalidPageToken,
+			wantMessage: "page_token: invalid page token",
+			wantField:   "page_token",
+		},
+		{
+			name:        "invalid credentials",
+			err:         service.ErrInvalidCredentials,
+			wantCode:    codes.Unauthenticated,
+			wantReason:  apperr.ReasonInvalidCredentials,
+			wantMessage: "invalid email or password",
+		},
+		{
+			name:        "invalid refresh token",
+			err:         service.ErrInvalidRefreshToken,
+			wantCode:    codes.Unauthenticated,
+			wantReason:  apperr.ReasonInvalidRefreshToken,
+			wantMessage: "invalid refresh token",
+		},
+		{
+			name:        "reused refresh token looks invalid",
+			err:         service.ErrRefreshTokenReused,
+			wantCode:    codes.Unauthenticated,
+			wantReason:  apperr.ReasonInvalidRefreshToken,
+			wantMessage: "invalid refresh token",
+		},
+		{
+			name:        "invalid access token",
+			err:         service.ErrInvalidAccessToken,
+			wantCode:    codes.Unauthenticated,
+			wantReason:  apperr.ReasonInvalidAccessToken,
+			wantMessage: "invalid access token",
+		},
+		{
+			name:        "unknown error is hidden",
+			err:         errors.New("connection refused"),
+			wantCode:    codes.Internal,
+			wantReason:  apperr.ReasonInternal,
+			wantMessage: codes.Internal.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, d := statusOf(t, apperr.ToStatus(context.Background(), tt.err))
+
+			if st.Code() != tt.wantCode {
+				t.Errorf("code = %v, want %v", st.Code(), tt.wantCode)
+			}
+
+			if st.Message() != tt.wantMessage {
+				t.Errorf("message = %q, want %q", st.Message(), tt.wantMessage)
+			}
+
+			if d.info.GetReason() != tt.wantReason {
+				t.Errorf("reason = %q, want %q", d.info.GetReason(), tt.wantReason)
+			}
+
+			if d.retry != nil {
+				t.Error("unexpected RetryInfo")
+			}
+
+			if tt.wantField == "" {
+				if d.badRequest != nil {
+					t.Error("unexpected BadRequest")
+				}
+
+				return
+			}
+
+			violations := d.badRequest.GetFieldViolations()
+			if len(violations) != 1 || violations[0].GetField() != tt.wantField {
+				t.Errorf("BadRequest violations = %v, want one for %q", violations, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestToStatusLocked(t *testing.T) {
+	until := time.Now().Add(90*time.Second + 500*time.Millisecond)
+	err := &service.LockedError{Until: until}
+
+	st, d := statusOf(t, apperr.ToStatus(context.Background(), err))
+
+	if st.Code() != codes.ResourceExhausted {
+		t.Errorf("code = %v, want %v", st.Code(), codes.ResourceExhausted)
+	}
+
+	if d.info.GetReason() != apperr.ReasonAccountLocked {
+		t.Errorf("reason = %q, want %q", d.info.GetReason(), apperr.ReasonAccountLocked)
+	}
+
+	if got, want := d.info.GetMetadata()["locked_until"], until.UTC().Format(time.RFC3339); got != want {
+		t.Errorf("locked_until = %q, want %q", got, want)
+	}
+
+	if d.retry == nil {
+		t.Fatal("status has no RetryInfo")
+	}
+
+	// Задержка округляется вверх до целых секунд.
+	if got := d.retry.GetRetryDelay().AsDuration(); got != 91*time.Second {
+		t.Errorf("retry delay = %v, want %v", got, 91*time.Second)
+	}
+}
+
+func TestInvalidArgument(t *testing.T) {
+	err := apperr.InvalidArgument(apperr.ReasonInvalidArgument,
+		apperr.FieldViolation{Field: "email", Description: "value is required"},
+		apperr.FieldViolation{Field: "filter.roles[1]", Description: "value must not be UNSPECIFIED"},
+	)
+
+	st, d := statusOf(t, err)
+
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("code = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+
+	wantMessage := "email: value is required; filter.roles[1]: value must not be UNSPECIFIED"
+	if st.Message() != wantMessage {
+		t.Errorf("message = %q, want %q", st.Message(), wantMessage)
+	}
+
+	if d.info.GetReason() != apperr.ReasonInvalidArgument {
+		t.Errorf("reason = %q, want %q", d.info.GetReason(), apperr.ReasonInvalidArgument)
+	}
+
+	violations := d.badRequest.GetFieldViolations()
+	if len(violations) != 2 || violations[1].GetField() != "filter.roles[1]" {
+		t.Errorf("BadRequest violations = %v, want email and filter.roles[1]", violations)
+	}
+}
@@ -0,0 +1,123 @@
+// Package certs loads TLS certificates for the gRPC server and reloads them from disk.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var (
+	errFailedLoadKeyPair  = errors.New("failed to load certificate key pair")
+	errFailedLoadClientCA = errors.New("failed to load client ca")
+	errNoCertificates     = errors.New("no certificates found")
+)
+
+// Reloader хранит сертификат сервера и пул корневых сертификатов клиентов и позволяет
+// заменить их на лету: новые TLS-рукопожатия используют уже обновлённые файлы.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+}
+
+// NewReloader загружает сертификат и ключ сервера и, если clientCAFile не пуст,
+// корневые сертификаты, которыми подписаны сертификаты клиентов.
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload перечитывает файлы. При ошибке продолжают действовать прежние сертификаты.
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errFailedLoadKeyPair, err)
+	}
+
+	var clientCA *x509.CertPool
+
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errFailedLoadClientCA, err)
+		}
+
+		clientCA = x509.NewCertPool()
+		if !clientCA.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("%w: %w: %s", errFailedLoadClientCA, errNoCertificates, r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.clientCA = clientCA
+
+	return nil
+}
+
+// Files возвращает пути ко всем файлам, за изменением которых нужно следить.
+func (r *Reloader) Files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		files = append(files, r.clientCAFile)
+	}
+
+	return files
+}
+
+// ServerConfig возвращает TLS-конфигурацию сервера с минимальной версией протокола minVersion.
+// Если задан пул корневых сертификатов клиентов, сервер требует и проверяет сертификат клиента (mTLS).
+func (r *Reloader) ServerConfig(minVersion uint16) *tls.Config {
+	return &tls.Config{
+		MinVersion: minVersion,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			config := &tls.Config{
+				MinVersion:   minVersion,
+				Certificates: []tls.Certificate{*r.cert},
+			}
+
+			if r.clientCA != nil {
+				config.ClientCAs = r.clientCA
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+
+			return config, nil
+		},
+	}
+}
+
+// Identity возвращает идентификатор владельца сертификата: первый URI из SAN (например,
+// SPIFFE ID), иначе первое DNS-имя из SAN, иначе Common Name.
+func Identity(cert *x509.Certificate) string {
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+
+	return cert.Subject.CommonName
+}
@@ -0,0 +1,61 @@
+// Package cleanup periodically deletes expired records from storage.
+package cleanup
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Task — одна задача очистки. Run удаляет устаревшие записи и возвращает их число.
+type Task struct {
+	Name string
+	Run  func(ctx context.Context) (int64, error)
+}
+
+// Runner раз в interval выполняет задачи очистки по порядку.
+type Runner struct {
+	interval time.Duration
+	tasks    []Task
+}
+
+// NewRunner создаёт Runner, выполняющий tasks раз в interval.
+func NewRunner(interval time.Duration, tasks ...Task) *Runner {
+	return &Runner{interval: interval, tasks: tasks}
+}
+
+// Run выполняет задачи сразу и затем раз в interval, пока не отменён ctx.
+// Ошибка задачи логируется и не мешает остальным; задача повторится на следующем такте.
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep выполняет все задачи один раз.
+func (r *Runner) sweep(ctx context.Context) {
+	for _, task := range r.tasks {
+		if ctx.Err() != nil {
+			return
+		}
+
+		deleted, err := task.Run(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to clean up", slog.String("task", task.Name), slog.Any("error", err))
+			continue
+		}
+
+		if deleted > 0 {
+			slog.InfoContext(ctx, "cleaned up", slog.String("task", task.Name), slog.Int64("deleted", deleted))
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package cleanup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/based-chat/auth/internal/cleanup"
+)
+
+func TestRunnerRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var failed, succeeded atomic.Int32
+
+	runner := cleanup.NewRunner(time.Millisecond,
+		cleanup.Task{
+			Name: "failing",
+			Run: func(context.Context) (int64, error) {
+				failed.Add(1)
+				return 0, errors.New("boom")
+			},
+		},
+		cleanup.Task{
+			Name: "succeeding",
+			Run: func(context.Context) (int64, error) {
+				if succeeded.Add(1) == 3 {
+					cancel()
+				}
+
+				return 1, nil
+			},
+		},
+	)
+
+	done := make(chan struct{})
+
+	go func() {
+		runner.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run() did not return after the context was cancelled")
+	}
+
+	// Ошибка одной задачи не мешает следующей, и на каждом такте выполняются обе.
+	if got := succeeded.Load(); got != 3 {
+		t.Errorf("succeeding task ran %d times, want 3", got)
+	}
+
+	if got := failed.Load(); got != 3 {
+		t.Errorf("failing task ran %d times, want 3", got)
+	}
+}
@@ -0,0 +1,33 @@
+// Package db describes the PostgreSQL client used by repositories.
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// DB — минимальный набор методов pgx, необходимый репозиториям.
+// Ему удовлетворяют как *pgx.Conn, так и пул соединений. Первая строка запроса
+// "-- name: <репозиторий>.<метод>" задаёт его имя в трассировке.
+type DB interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Handler — функция, выполняемая внутри транзакции. Контекст ctx несёт транзакцию,
+// поэтому репозитории, вызванные с ним, автоматически к ней присоединяются.
+type Handler func(ctx context.Context) error
+
+// TxManager выполняет функции в транзакциях PostgreSQL.
+// Если в контексте уже есть транзакция, функция выполняется в ней, без вложенной транзакции.
+type TxManager interface {
+	// ReadCommitted выполняет fn в транзакции с уровнем изоляции READ COMMITTED.
+	ReadCommitted(ctx context.Context, fn Handler) error
+	// Serializable выполняет fn в транзакции с уровнем изоляции SERIALIZABLE.
+	Serializable(ctx context.Context, fn Handler) error
+	// Run выполняет fn в транзакции с заданными параметрами.
+	Run(ctx context.Context, opts pgx.TxOptions, fn Handler) error
+}
@@ -0,0 +1,102 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/based-chat/auth/internal/client/db"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ db.DB = (*Client)(nil)
+
+type txKey struct{}
+
+// Client выполняет запросы в транзакции из контекста, а без неё — напрямую в пуле.
+// Репозитории, построенные поверх Client, прозрачно присоединяются к транзакциям TxManager.
+// Каждый запрос выполняется в дочернем спане трассировки, названном по имени запроса.
+type Client struct {
+	pool   *pgxpool.Pool
+	tracer trace.Tracer
+}
+
+// NewClient создаёт клиент поверх пула соединений.
+func NewClient(pool *pgxpool.Pool) *Client {
+	return &Client{
+		pool:   pool,
+		tracer: otel.Tracer(instrumentationName),
+	}
+}
+
+// Exec выполняет запрос без результата.
+func (c *Client) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	ctx, span := c.startSpan(ctx, sql)
+
+	var (
+		tag pgconn.CommandTag
+		err error
+	)
+
+	if tx, ok := txFromContext(ctx); ok {
+		tag, err = tx.Exec(ctx, sql, args...)
+	} else {
+		tag, err = c.pool.Exec(ctx, sql, args...)
+	}
+
+	endSpan(span, err)
+
+	return tag, err
+}
+
+// Query выполняет запрос, возвращающий строки. Спан закрывается, когда строки прочитаны
+// или закрыты.
+func (c *Client) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	ctx, span := c.startSpan(ctx, sql)
+
+	var (
+		rows pgx.Rows
+		err  error
+	)
+
+	if tx, ok := txFromContext(ctx); ok {
+		rows, err = tx.Query(ctx, sql, args...)
+	} else {
+		rows, err = c.pool.Query(ctx, sql, args...)
+	}
+
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+// QueryRow выполняет запрос, возвращающий не более одной строки. Спан закрывается после Scan.
+func (c *Client) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	ctx, span := c.startSpan(ctx, sql)
+
+	var row pgx.Row
+
+	if tx, ok := txFromContext(ctx); ok {
+		row = tx.QueryRow(ctx, sql, args...)
+	} else {
+		row = c.pool.QueryRow(ctx, sql, args...)
+	}
+
+	return &tracedRow{row: row, span: span}
+}
+
+// contextWithTx возвращает копию ctx, несущую транзакцию tx.
+func contextWithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// txFromContext возвращает транзакцию из ctx, если она есть.
+func txFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
@@ -0,0 +1,71 @@
+// Package pg creates the PostgreSQL connection pool used by repositories.
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/based-chat/auth/internal/client/db"
+	"github.com/based-chat/auth/internal/config"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+var _ db.DB = (*pgxpool.Pool)(nil)
+
+// statementTimeoutParam — параметр сессии PostgreSQL, ограничивающий время выполнения запроса.
+const statementTimeoutParam = "statement_timeout"
+
+var (
+	errFailedParseConfig = errors.New("failed to parse postgres config")
+	errFailedConnect     = errors.New("failed to connect to postgres")
+	errFailedPing        = errors.New("failed to ping postgres")
+)
+
+// NewPool создаёт пул соединений с параметрами из cfg и проверяет доступность базы.
+func NewPool(ctx context.Context, cfg config.PostgresConfig) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedParseConfig, err)
+	}
+
+	poolConfig.MaxConns = cfg.MaxConns()
+	poolConfig.MinConns = cfg.MinConns()
+	poolConfig.MaxConnLifetime = cfg.MaxConnLifetime()
+	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime()
+
+	// DSN может смениться на лету, если он читается из файла секрета. Новые соединения
+	// открываются с актуальными адресом и учётными данными, открытые доживают свой срок.
+	poolConfig.BeforeConnect = func(_ context.Context, connConfig *pgx.ConnConfig) error {
+		current, err := pgx.ParseConfig(cfg.DSN())
+		if err != nil {
+			return fmt.Errorf("%w: %w", errFailedParseConfig, err)
+		}
+
+		connConfig.Host = current.Host
+		connConfig.Port = current.Port
+		connConfig.Database = current.Database
+		connConfig.User = current.User
+		connConfig.Password = current.Password
+
+		return nil
+	}
+
+	if timeout := cfg.StatementTimeout(); timeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams[statementTimeoutParam] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+
+	pool, err := pgxpool.ConnectConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedConnect, err)
+	}
+
+	if err = pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("%w: %w", errFailedPing, err)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,115 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName — имя, под которым клиент создаёт спаны запросов.
+	instrumentationName = "github.com/based-chat/auth/internal/client/db/pg"
+
+	// queryNamePrefix начинает первую строку запроса, задающую его имя: "-- name: user.Get".
+	queryNamePrefix = "-- name:"
+	// unnamedQuery — имя спана для запроса без строки с именем.
+	unnamedQuery = "query"
+)
+
+// queryName возвращает имя запроса из его первой строки "-- name: ...". В спан попадает
+// только имя: ни текст запроса, ни его параметры не записываются.
+func queryName(sql string) string {
+	line, _, _ := strings.Cut(strings.TrimLeft(sql, "\n"), "\n")
+
+	name, ok := strings.CutPrefix(line, queryNamePrefix)
+	if !ok {
+		return unnamedQuery
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		return unnamedQuery
+	}
+
+	return name
+}
+
+// startSpan открывает дочерний спан для запроса sql. Запрос вне трассируемого вызова,
+// например фоновая проверка готовности, спана не получает: корневые спаны отдельных запросов
+// без вызова, к которому они относятся, только засоряют трассировки.
+func (c *Client) startSpan(ctx context.Context, sql string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	name := queryName(sql)
+
+	return c.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBQuerySummary(name),
+		),
+	)
+}
+
+// endSpan записывает ошибку запроса в span и закрывает его. Отсутствие строк ошибкой не считается.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// tracedRows закрывает спан запроса, когда строки прочитаны до конца или закрыты.
+type tracedRows struct {
+	pgx.Rows
+
+	span trace.Span
+	once sync.Once
+}
+
+// Next переходит к следующей строке и закрывает спан после последней.
+func (r *tracedRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+
+	r.end()
+
+	return false
+}
+
+// Close закрывает строки и спан.
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	r.end()
+}
+
+// end закрывает спан с ошибкой чтения строк, если она была.
+func (r *tracedRows) end() {
+	r.once.Do(func() {
+		endSpan(r.span, r.Rows.Err())
+	})
+}
+
+// tracedRow закрывает спан запроса после чтения строки.
+type tracedRow struct {
+	row  pgx.Row
+	span trace.Span
+}
+
+// Scan читает строку и закрывает спан.
+func (r *tracedRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	endSpan(r.span, err)
+
+	return err
+}
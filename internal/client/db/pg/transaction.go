@@ -0,0 +1,139 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/based-chat/auth/internal/client/db"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+var _ db.TxManager = (*TxManager)(nil)
+
+const (
+	// serializationFailure и deadlockDetected — коды ошибок PostgreSQL, после которых
+	// транзакцию безопасно повторить целиком.
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+
+	// retryBaseDelay и retryMaxDelay ограничивают паузу перед повтором транзакции: она выбирается
+	// случайно до retryBaseDelay·2^попытка, но не больше retryMaxDelay, чтобы конфликтующие
+	// транзакции не повторялись одновременно.
+	retryBaseDelay = 10 * time.Millisecond
+	retryMaxDelay  = 500 * time.Millisecond
+)
+
+var (
+	errFailedBeginTx    = errors.New("failed to begin transaction")
+	errFailedCommitTx   = errors.New("failed to commit transaction")
+	errFailedRollbackTx = errors.New("failed to roll back transaction")
+)
+
+// TxBeginner начинает транзакции; ему удовлетворяют *pgxpool.Pool и *pgx.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error)
+}
+
+// TxManager выполняет функции в транзакциях пула и повторяет их при конфликтах сериализации.
+type TxManager struct {
+	pool       TxBeginner
+	maxRetries int
+}
+
+// NewTxManager создаёт менеджер транзакций. maxRetries — сколько раз транзакция повторяется
+// после конфликта сериализации или взаимоблокировки, прежде чем вернуть ошибку.
+func NewTxManager(pool TxBeginner, maxRetries int) *TxManager {
+	return &TxManager{pool: pool, maxRetries: maxRetries}
+}
+
+// ReadCommitted выполняет fn в транзакции с уровнем изоляции READ COMMITTED.
+func (m *TxManager) ReadCommitted(ctx context.Context, fn db.Handler) error {
+	return m.Run(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, fn)
+}
+
+// Serializable выполняет fn в транзакции с уровнем изоляции SERIALIZABLE.
+func (m *TxManager) Serializable(ctx context.Context, fn db.Handler) error {
+	return m.Run(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}, fn)
+}
+
+// Run выполняет fn в транзакции с параметрами opts. Если в ctx уже есть транзакция, fn
+// выполняется в ней, а фиксацией и повторами управляет внешний вызов. Иначе транзакция
+// фиксируется при успехе fn и откатывается при ошибке или панике; панику Run затем
+// пробрасывает дальше. Если PostgreSQL отклонил транзакцию из-за конфликта сериализации
+// или взаимоблокировки, она после случайной паузы повторяется целиком.
+func (m *TxManager) Run(ctx context.Context, opts pgx.TxOptions, fn db.Handler) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		err = m.run(ctx, opts, fn)
+		if !isRetryable(err) || attempt == m.maxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(retryDelay(attempt))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %w", err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// run выполняет одну попытку транзакции.
+func (m *TxManager) run(ctx context.Context, opts pgx.TxOptions, fn db.Handler) (err error) {
+	tx, err := m.pool.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errFailedBeginTx, err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback(ctx)
+			panic(r)
+		}
+
+		if err == nil {
+			if commitErr := tx.Commit(ctx); commitErr != nil {
+				err = fmt.Errorf("%w: %w", errFailedCommitTx, commitErr)
+			}
+
+			return
+		}
+
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
+			err = fmt.Errorf("%w: %w; %w", errFailedRollbackTx, rollbackErr, err)
+		}
+	}()
+
+	return fn(contextWithTx(ctx, tx))
+}
+
+// retryDelay возвращает случайную паузу перед повтором после попытки attempt.
+func retryDelay(attempt int) time.Duration {
+	ceiling := retryBaseDelay
+	for i := 0; i < attempt && ceiling < retryMaxDelay; i++ {
+		ceiling *= 2
+	}
+
+	return rand.N(min(ceiling, retryMaxDelay))
+}
+
+// isRetryable сообщает, можно ли повторить транзакцию, завершившуюся ошибкой err.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
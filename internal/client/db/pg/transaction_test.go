@@ -0,0 +1,170 @@
+package pg_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/based-chat/auth/internal/client/db/pg"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// fakeTx запоминает, была ли транзакция зафиксирована или откачена.
+type fakeTx struct {
+	pgx.Tx
+
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+// fakePool запоминает начатые транзакции.
+type fakePool struct {
+	txs []*fakeTx
+}
+
+func (p *fakePool) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+	tx := &fakeTx{}
+	p.txs = append(p.txs, tx)
+
+	return tx, nil
+}
+
+func TestTxManagerCommitRollback(t *testing.T) {
+	errFn := errors.New("fn failed")
+
+	tests := []struct {
+		name         string
+		fnErr        error
+		wantCommit   bool
+		wantRollback bool
+	}{
+		{name: "success", fnErr: nil, wantCommit: true},
+		{name: "error", fnErr: errFn, wantRollback: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &fakePool{}
+			m := pg.NewTxManager(pool, 3)
+
+			err := m.ReadCommitted(context.Background(), func(context.Context) error { return tt.fnErr })
+			if !errors.Is(err, tt.fnErr) {
+				t.Errorf("ReadCommitted() error = %v, want %v", err, tt.fnErr)
+			}
+
+			if len(pool.txs) != 1 {
+				t.Fatalf("began %d transactions, want 1", len(pool.txs))
+			}
+
+			tx := pool.txs[0]
+			if tx.committed != tt.wantCommit || tx.rolledBack != tt.wantRollback {
+				t.Errorf("committed = %t, rolled back = %t, want %t and %t",
+					tx.committed, tx.rolledBack, tt.wantCommit, tt.wantRollback)
+			}
+		})
+	}
+}
+
+func TestTxManagerPanic(t *testing.T) {
+	pool := &fakePool{}
+	m := pg.NewTxManager(pool, 3)
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("recovered %v, want the original panic", r)
+		}
+
+		if len(pool.txs) != 1 || !pool.txs[0].rolledBack || pool.txs[0].committed {
+			t.Error("transaction was not rolled back before the panic propagated")
+		}
+	}()
+
+	_ = m.ReadCommitted(context.Background(), func(context.Context) error { panic("boom") })
+
+	t.Error("ReadCommitted() returned, want the panic to propagate")
+}
+
+func TestTxManagerNested(t *testing.T) {
+	pool := &fakePool{}
+	m := pg.NewTxManager(pool, 3)
+
+	err := m.Serializable(context.Background(), func(ctx context.Context) error {
+		return m.ReadCommitted(ctx, func(context.Context) error { return nil })
+	})
+	if err != nil {
+		t.Fatalf("Serializable() error = %v", err)
+	}
+
+	// Вложенный вызов выполняется во внешней транзакции, а не начинает свою.
+	if len(pool.txs) != 1 || !pool.txs[0].committed {
+		t.Errorf("began %d transactions, want one committed", len(pool.txs))
+	}
+}
+
+func TestTxManagerRetry(t *testing.T) {
+	const maxRetries = 2
+
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, wantAttempts: maxRetries + 1},
+		{name: "deadlock", err: &pgconn.PgError{Code: "40P01"}, wantAttempts: maxRetries + 1},
+		{
+			name:         "wrapped serialization failure",
+			err:          fmt.Errorf("query: %w", &pgconn.PgError{Code: "40001"}),
+			wantAttempts: maxRetries + 1,
+		},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, wantAttempts: 1},
+		{name: "other error", err: errors.New("boom"), wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &fakePool{}
+			m := pg.NewTxManager(pool, maxRetries)
+
+			err := m.Serializable(context.Background(), func(context.Context) error { return tt.err })
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Serializable() error = %v, want %v", err, tt.err)
+			}
+
+			if len(pool.txs) != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", len(pool.txs), tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestTxManagerRetryCanceled(t *testing.T) {
+	pool := &fakePool{}
+	m := pg.NewTxManager(pool, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := m.Serializable(ctx, func(context.Context) error {
+		cancel()
+		return &pgconn.PgError{Code: "40001"}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Serializable() error = %v, want %v", err, context.Canceled)
+	}
+
+	if len(pool.txs) != 1 {
+		t.Errorf("attempts = %d, want no retries after cancellation", len(pool.txs))
+	}
+}
@@ -1,17 +1,106 @@
 package config
 
-import "github.com/joho/godotenv"
-
-// Load загружает переменные окружения из файла, указанного в path.
-// path — путь к файлу с переменными окружения (обычно ".env"); возвращает ошибку, полученную при попытке загрузки.
-func Load(path string) error {
-	return godotenv.Load(path)
-}
+import (
+	"log/slog"
+	"time"
+)
 
 type GRPCConfig interface {
 	Address() string
+	TLSEnabled() bool
+	TLSCertFile() string
+	TLSKeyFile() string
+	TLSClientCAFile() string
+	TLSMinVersion() uint16
+	TLSClientPrincipals() map[string]string
+	TLSAccessPrincipals() []string
 }
 
 type PostgresConfig interface {
 	DSN() string
+	MaxConns() int32
+	MinConns() int32
+	MaxConnLifetime() time.Duration
+	MaxConnIdleTime() time.Duration
+	StatementTimeout() time.Duration
+	TxMaxRetries() int
+}
+
+type PasswordConfig interface {
+	Argon2Memory() uint32
+	Argon2Iterations() uint32
+	Argon2Parallelism() uint8
+	Argon2SaltLength() uint32
+	Argon2KeyLength() uint32
+}
+
+type TokenConfig interface {
+	Issuer() string
+	Audience() string
+	AccessTokenTTL() time.Duration
+	RefreshTokenTTL() time.Duration
+	RefreshTokenRetention() time.Duration
+	SigningKeyFile() string
+	VerificationKeyFiles() []string
+}
+
+type HTTPConfig interface {
+	Address() string
+}
+
+type MetricsConfig interface {
+	Address() string
+}
+
+// LogFormat — формат записей журнала.
+type LogFormat string
+
+const (
+	// LogFormatJSON пишет каждую запись объектом JSON в отдельной строке.
+	LogFormatJSON LogFormat = "json"
+	// LogFormatText пишет записи парами key=value; удобно для локального запуска.
+	LogFormatText LogFormat = "text"
+)
+
+type LoggingConfig interface {
+	Level() slog.Level
+	Format() LogFormat
+}
+
+// TracingExporter — куда отправляются трассировки.
+type TracingExporter string
+
+const (
+	// TracingExporterNone отключает трассировку.
+	TracingExporterNone TracingExporter = "none"
+	// TracingExporterOTLP отправляет трассировки коллектору по OTLP/gRPC.
+	TracingExporterOTLP TracingExporter = "otlp"
+	// TracingExporterStdout печатает трассировки в stdout; удобно для локального запуска.
+	TracingExporterStdout TracingExporter = "stdout"
+	// TracingExporterFile дописывает трассировки в файл.
+	TracingExporterFile TracingExporter = "file"
+)
+
+type TracingConfig interface {
+	Exporter() TracingExporter
+	OTLPEndpoint() string
+	OTLPInsecure() bool
+	File() string
+	SampleRatio() float64
+}
+
+type UserConfig interface {
+	GetManyMaxIDs() int
+	PageTokenKey() string
+}
+
+type LoginConfig interface {
+	MaxFailedAttempts() int
+	LockoutDuration() time.Duration
+}
+
+type HealthConfig interface {
+	CheckInterval() time.Duration
+	CheckTimeout() time.Duration
+	ShutdownDelay() time.Duration
 }
@@ -0,0 +1,271 @@
+package env
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// redacted заменяет значения секретов при выводе конфигурации.
+	redacted = "REDACTED"
+
+	envSecretReloadInterval = "SECRET_RELOAD_INTERVAL"
+	envShutdownTimeout      = "SHUTDOWN_TIMEOUT"
+	envCleanupInterval      = "CLEANUP_INTERVAL"
+
+	defaultSecretReloadInterval = 30 * time.Second
+	defaultShutdownTimeout      = 15 * time.Second
+	defaultCleanupInterval      = time.Hour
+)
+
+// dsnPassword находит пароль в DSN формата "key=value".
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('[^']*'|\S+)`)
+
+// Config — полная конфигурация сервера.
+type Config struct {
+	GRPC     *GrpcConfig
+	HTTP     *HTTPConfig
+	Metrics  *MetricsConfig
+	Postgres *PostgresConfig
+	Password *PasswordConfig
+	Token    *TokenConfig
+	User     *UserConfig
+	Login    *LoginConfig
+	Health   *HealthConfig
+	Tracing  *TracingConfig
+	Logging  *LoggingConfig
+
+	// SecretReloadInterval — период проверки файлов секретов и ключей на изменения.
+	SecretReloadInterval time.Duration
+	// ShutdownTimeout — срок, за который сервер должен завершить текущие запросы при остановке.
+	ShutdownTimeout time.Duration
+	// CleanupInterval — период удаления устаревших записей из хранилища.
+	CleanupInterval time.Duration
+}
+
+// Setting — итоговое значение одного параметра конфигурации.
+type Setting struct {
+	Name  string
+	Value string
+}
+
+// NewConfig создаёт и проверяет все секции конфигурации. В отличие от отдельных
+// конструкторов, он не останавливается на первой ошибке, а возвращает все найденные
+// ошибки, объединённые errors.Join. Секция PostgreSQL читается, только если withPostgres.
+func NewConfig(withPostgres bool) (*Config, error) {
+	var (
+		cfg  Config
+		errs []error
+	)
+
+	collect := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	var err error
+
+	cfg.GRPC, err = NewGRPCConfig()
+	collect(err)
+
+	cfg.HTTP, err = NewHTTPConfig()
+	collect(err)
+
+	cfg.Metrics, err = NewMetricsConfig()
+	collect(err)
+
+	if withPostgres {
+		cfg.Postgres, err = NewPostgresConfig()
+		collect(err)
+	}
+
+	cfg.Password, err = NewPasswordConfig()
+	collect(err)
+
+	cfg.Token, err = NewTokenConfig()
+	collect(err)
+
+	cfg.User, err = NewUserConfig()
+	collect(err)
+
+	cfg.Login, err = NewLoginConfig()
+	collect(err)
+
+	cfg.Health, err = NewHealthConfig()
+	collect(err)
+
+	cfg.Tracing, err = NewTracingConfig()
+	collect(err)
+
+	cfg.Logging, err = NewLoggingConfig()
+	collect(err)
+
+	cfg.SecretReloadInterval, err = durationFromEnv(envSecretReloadInterval, defaultSecretReloadInterval)
+	collect(err)
+
+	cfg.ShutdownTimeout, err = durationFromEnv(envShutdownTimeout, defaultShutdownTimeout)
+	collect(err)
+
+	cfg.CleanupInterval, err = durationFromEnv(envCleanupInterval, defaultCleanupInterval)
+	collect(err)
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Settings возвращает итоговые значения параметров под именами переменных окружения.
+// Секреты в выводе скрыты.
+func (c *Config) Settings() []Setting {
+	var settings []Setting
+
+	add := func(name, value string) {
+		settings = append(settings, Setting{Name: name, Value: value})
+	}
+
+	addAddress := func(hostName, portName, address string) {
+		host, port, _ := net.SplitHostPort(address)
+		add(hostName, host)
+		add(portName, port)
+	}
+
+	addUint := func(name string, value uint64) {
+		add(name, strconv.FormatUint(value, 10))
+	}
+
+	addDuration := func(name string, value time.Duration) {
+		add(name, value.String())
+	}
+
+	addSecret := func(secret *Secret) {
+		switch {
+		case secret.Path() != "":
+			add(secret.Name()+fileSuffix, secret.Path())
+		case secret.Value() != "":
+			add(secret.Name(), redacted)
+		default:
+			add(secret.Name(), "")
+		}
+	}
+
+	addAddress(envGRPCHost, envGRPCPort, c.GRPC.Address())
+	add(envGRPCTLSCertFile, c.GRPC.TLSCertFile())
+	add(envGRPCTLSKeyFile, c.GRPC.TLSKeyFile())
+	add(envGRPCTLSClientCAFile, c.GRPC.TLSClientCAFile())
+	add(envGRPCTLSMinVersion, c.GRPC.tlsMinVersionName)
+	add(envGRPCTLSClientPrincipals, joinPairs(c.GRPC.TLSClientPrincipals()))
+	add(envGRPCTLSAccessPrincipals, strings.Join(c.GRPC.TLSAccessPrincipals(), ","))
+	addAddress(envHTTPHost, envHTTPPort, c.HTTP.Address())
+	addAddress(envMetricsHost, envMetricsPort, c.Metrics.Address())
+
+	if p := c.Postgres; p != nil {
+		if path := p.DSNSecret().Path(); path != "" {
+			add(envPostgresDSN+fileSuffix, path)
+		} else {
+			add(envPostgresDSN, redactDSN(p.DSN()))
+		}
+
+		addUint(envPostgresMaxConns, uint64(p.MaxConns()))
+		addUint(envPostgresMinConns, uint64(p.MinConns()))
+		addDuration(envPostgresMaxConnLifetime, p.MaxConnLifetime())
+		addDuration(envPostgresMaxConnIdleTime, p.MaxConnIdleTime())
+		addDuration(envPostgresStatementTimeout, p.StatementTimeout())
+		addUint(envPostgresTxMaxRetries, uint64(p.TxMaxRetries()))
+	}
+
+	addUint(envArgon2Memory, uint64(c.Password.Argon2Memory()))
+	addUint(envArgon2Iterations, uint64(c.Password.Argon2Iterations()))
+	addUint(envArgon2Parallelism, uint64(c.Password.Argon2Parallelism()))
+	addUint(envArgon2SaltLength, uint64(c.Password.Argon2SaltLength()))
+	addUint(envArgon2KeyLength, uint64(c.Password.Argon2KeyLength()))
+
+	add(envTokenIssuer, c.Token.Issuer())
+	add(envTokenAudience, c.Token.Audience())
+	addDuration(envTokenAccessTTL, c.Token.AccessTokenTTL())
+	addDuration(envTokenRefreshTTL, c.Token.RefreshTokenTTL())
+	addDuration(envTokenRefreshRetention, c.Token.RefreshTokenRetention())
+	add(envTokenSigningKeyFile, c.Token.SigningKeyFile())
+	add(envTokenVerificationKeyFiles, strings.Join(c.Token.VerificationKeyFiles(), ","))
+
+	addUint(envUserGetManyMaxIDs, uint64(c.User.GetManyMaxIDs()))
+	addSecret(c.User.PageTokenKeySecret())
+
+	addUint(envLoginMaxFailedAttempts, uint64(c.Login.MaxFailedAttempts()))
+	addDuration(envLoginLockoutDuration, c.Login.LockoutDuration())
+
+	addDuration(envHealthCheckInterval, c.Health.CheckInterval())
+	addDuration(envHealthCheckTimeout, c.Health.CheckTimeout())
+	addDuration(envHealthShutdownDelay, c.Health.ShutdownDelay())
+
+	add(envTracingExporter, string(c.Tracing.Exporter()))
+	add(envTracingOTLPEndpoint, c.Tracing.OTLPEndpoint())
+	add(envTracingOTLPInsecure, strconv.FormatBool(c.Tracing.OTLPInsecure()))
+	add(envTracingFile, c.Tracing.File())
+	add(envTracingSampleRatio, strconv.FormatFloat(c.Tracing.SampleRatio(), 'g', -1, 64))
+
+	add(envLogLevel, strings.ToLower(c.Logging.Level().String()))
+	add(envLogFormat, string(c.Logging.Format()))
+
+	addDuration(envSecretReloadInterval, c.SecretReloadInterval)
+	addDuration(envShutdownTimeout, c.ShutdownTimeout)
+	addDuration(envCleanupInterval, c.CleanupInterval)
+
+	return settings
+}
+
+// Secrets возвращает секреты, прочитанные из файлов NAME_FILE, которые перечитываются при изменении
+// файла. Сейчас это только POSTGRES_DSN: ключи подписи и TLS-сертификаты и так задаются путями
+// к файлам и отслеживаются отдельно, а ключ токенов страниц читается только при запуске.
+func (c *Config) Secrets() []*Secret {
+	var secrets []*Secret
+
+	if c.Postgres != nil && c.Postgres.DSNSecret().Path() != "" {
+		secrets = append(secrets, c.Postgres.DSNSecret())
+	}
+
+	return secrets
+}
+
+// joinPairs записывает отображение списком пар key=value через запятую в порядке ключей.
+func joinPairs(pairs map[string]string) string {
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for i, key := range keys {
+		keys[i] = key + "=" + pairs[key]
+	}
+
+	return strings.Join(keys, ",")
+}
+
+// redactDSN скрывает пароль в DSN формата URL или "key=value".
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+
+		query := u.Query()
+		if query.Has("password") {
+			query.Set("password", redacted)
+			u.RawQuery = query.Encode()
+		}
+
+		return u.String()
+	}
+
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+redacted)
+}
@@ -0,0 +1,86 @@
+// Package env provides configuration read from environment variables.
+package env
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errInvalidEnvValue = errors.New("invalid environment variable value")
+)
+
+// uintFromEnv читает из переменной окружения name беззнаковое число разрядности bitSize,
+// не меньшее minValue. Для пустой переменной возвращается defaultValue.
+func uintFromEnv(name string, defaultValue, minValue uint64, bitSize int) (uint64, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseUint(raw, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", errInvalidEnvValue, name, err)
+	}
+
+	if value < minValue {
+		return 0, fmt.Errorf("%w: %s must be at least %d", errInvalidEnvValue, name, minValue)
+	}
+
+	return value, nil
+}
+
+// durationFromEnv читает из переменной окружения name положительную длительность
+// в формате time.ParseDuration. Для пустой переменной возвращается defaultValue.
+func durationFromEnv(name string, defaultValue time.Duration) (time.Duration, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", errInvalidEnvValue, name, err)
+	}
+
+	if value <= 0 {
+		return 0, fmt.Errorf("%w: %s must be positive", errInvalidEnvValue, name)
+	}
+
+	return value, nil
+}
+
+// listFromEnv читает из переменной окружения name список значений через запятую,
+// отбрасывая пробелы и пустые элементы.
+func listFromEnv(name string) []string {
+	var values []string
+
+	for _, value := range strings.Split(os.Getenv(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// addressFromEnv читает адрес host:port из переменных hostName и portName.
+// Для пустых переменных используются defaultHost и defaultPort; порт должен быть числом от 1 до 65535.
+func addressFromEnv(hostName, portName, defaultHost string, defaultPort uint64) (string, error) {
+	host := os.Getenv(hostName)
+	if host == "" {
+		host = defaultHost
+	}
+
+	port, err := uintFromEnv(portName, defaultPort, 1, 16)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, strconv.FormatUint(port, 10)), nil
+}
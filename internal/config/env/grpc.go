@@ -1,38 +1,161 @@
 package env
 
 import (
-	"net"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/based-chat/auth/internal/config"
 )
 
 var _ config.GRPCConfig = (*GrpcConfig)(nil)
 
+const (
+	envGRPCHost                = "GRPC_HOST"
+	envGRPCPort                = "GRPC_PORT"
+	envGRPCTLSCertFile         = "GRPC_TLS_CERT_FILE"
+	envGRPCTLSKeyFile          = "GRPC_TLS_KEY_FILE"
+	envGRPCTLSClientCAFile     = "GRPC_TLS_CLIENT_CA_FILE"
+	envGRPCTLSMinVersion       = "GRPC_TLS_MIN_VERSION"
+	envGRPCTLSClientPrincipals = "GRPC_TLS_CLIENT_PRINCIPALS"
+	envGRPCTLSAccessPrincipals = "GRPC_TLS_ACCESS_PRINCIPALS"
+
+	defaultGRPCHost          = "localhost"
+	defaultGRPCPort          = 50052
+	defaultGRPCTLSMinVersion = "1.2"
+)
+
+var (
+	errTLSKeyPairIncomplete     = errors.New("GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE must be set together")
+	errTLSClientCAWithoutTLS    = errors.New("GRPC_TLS_CLIENT_CA_FILE requires GRPC_TLS_CERT_FILE")
+	errTLSPrincipalsWithoutMTLS = errors.New("GRPC_TLS_CLIENT_PRINCIPALS requires GRPC_TLS_CLIENT_CA_FILE")
+	errTLSAccessWithoutMTLS     = errors.New("GRPC_TLS_ACCESS_PRINCIPALS requires GRPC_TLS_CLIENT_CA_FILE")
+)
+
+// tlsVersions сопоставляет допустимым значениям GRPC_TLS_MIN_VERSION версии протокола.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
 type GrpcConfig struct {
-	host string
-	port string
+	address string
+
+	tlsCertFile         string
+	tlsKeyFile          string
+	tlsClientCAFile     string
+	tlsMinVersion       uint16
+	tlsMinVersionName   string
+	tlsClientPrincipals map[string]string
+	tlsAccessPrincipals []string
 }
 
 // Address возвращает адрес gRPC-сервера в формате host:port.
 func (g *GrpcConfig) Address() string {
-	return net.JoinHostPort(g.host, g.port)
+	return g.address
+}
+
+// TLSEnabled сообщает, обслуживает ли сервер соединения по TLS.
+func (g *GrpcConfig) TLSEnabled() bool {
+	return g.tlsCertFile != ""
+}
+
+// TLSCertFile возвращает путь к PEM-файлу сертификата сервера.
+func (g *GrpcConfig) TLSCertFile() string {
+	return g.tlsCertFile
+}
+
+// TLSKeyFile возвращает путь к PEM-файлу закрытого ключа сервера.
+func (g *GrpcConfig) TLSKeyFile() string {
+	return g.tlsKeyFile
+}
+
+// TLSClientCAFile возвращает путь к корневым сертификатам клиентов.
+// Непустое значение включает mTLS: сервер требует сертификат клиента.
+func (g *GrpcConfig) TLSClientCAFile() string {
+	return g.tlsClientCAFile
+}
+
+// TLSMinVersion возвращает минимальную версию TLS.
+func (g *GrpcConfig) TLSMinVersion() uint16 {
+	return g.tlsMinVersion
+}
+
+// TLSClientPrincipals сопоставляет идентификаторам клиентских сертификатов имена сервисов.
+// Пустое отображение означает, что имя сервиса совпадает с идентификатором сертификата.
+func (g *GrpcConfig) TLSClientPrincipals() map[string]string {
+	return g.tlsClientPrincipals
+}
+
+// TLSAccessPrincipals возвращает имена сервисов, которым разрешено вызывать AccessV1.
+// Пустой список означает, что AccessV1 доступен любому клиенту с доверенным сертификатом.
+func (g *GrpcConfig) TLSAccessPrincipals() []string {
+	return g.tlsAccessPrincipals
 }
 
-// Возвращает указатель на GrpcConfig и ошибку (в текущей реализации всегда nil).
+// NewGRPCConfig создаёт конфигурацию gRPC-сервера из переменных GRPC_HOST, GRPC_PORT и GRPC_TLS_*.
+// Незаданные переменные заменяются значениями по умолчанию, некорректные значения приводят к ошибке.
+// GRPC_TLS_CLIENT_PRINCIPALS — список пар identity=principal через запятую,
+// GRPC_TLS_ACCESS_PRINCIPALS — список имён сервисов через запятую.
 func NewGRPCConfig() (*GrpcConfig, error) {
-	host := os.Getenv("GRPC_HOST")
-	if host == "" {
-		host = "localhost"
+	address, err := addressFromEnv(envGRPCHost, envGRPCPort, defaultGRPCHost, defaultGRPCPort)
+	if err != nil {
+		return nil, err
+	}
+
+	certFile := os.Getenv(envGRPCTLSCertFile)
+	keyFile := os.Getenv(envGRPCTLSKeyFile)
+	clientCAFile := os.Getenv(envGRPCTLSClientCAFile)
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, errTLSKeyPairIncomplete
+	}
+
+	if clientCAFile != "" && certFile == "" {
+		return nil, errTLSClientCAWithoutTLS
+	}
+
+	minVersionName := os.Getenv(envGRPCTLSMinVersion)
+	if minVersionName == "" {
+		minVersionName = defaultGRPCTLSMinVersion
 	}
 
-	port := os.Getenv("GRPC_PORT")
-	if port == "" {
-		port = "50052"
+	minVersion, ok := tlsVersions[minVersionName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s must be 1.2 or 1.3", errInvalidEnvValue, envGRPCTLSMinVersion)
+	}
+
+	principals := make(map[string]string)
+
+	for _, pair := range listFromEnv(envGRPCTLSClientPrincipals) {
+		identity, principal, ok := strings.Cut(pair, "=")
+		if !ok || identity == "" || principal == "" {
+			return nil, fmt.Errorf("%w: %s: %q is not identity=principal", errInvalidEnvValue, envGRPCTLSClientPrincipals, pair)
+		}
+
+		principals[strings.TrimSpace(identity)] = strings.TrimSpace(principal)
+	}
+
+	if len(principals) > 0 && clientCAFile == "" {
+		return nil, errTLSPrincipalsWithoutMTLS
+	}
+
+	accessPrincipals := listFromEnv(envGRPCTLSAccessPrincipals)
+	if len(accessPrincipals) > 0 && clientCAFile == "" {
+		return nil, errTLSAccessWithoutMTLS
 	}
 
 	return &GrpcConfig{
-		host: host,
-		port: port,
+		address: address,
+
+		tlsCertFile:         certFile,
+		tlsKeyFile:          keyFile,
+		tlsClientCAFile:     clientCAFile,
+		tlsMinVersion:       minVersion,
+		tlsMinVersionName:   minVersionName,
+		tlsClientPrincipals: principals,
+		tlsAccessPrincipals: accessPrincipals,
 	}, nil
 }
@@ -0,0 +1,73 @@
+package env
+
+import (
+	"errors"
+	"time"
+
+	"github.com/based-chat/auth/internal/config"
+)
+
+var _ config.HealthConfig = (*HealthConfig)(nil)
+
+const (
+	envHealthCheckInterval = "HEALTH_CHECK_INTERVAL"
+	envHealthCheckTimeout  = "HEALTH_CHECK_TIMEOUT"
+	envHealthShutdownDelay = "HEALTH_SHUTDOWN_DELAY"
+
+	defaultHealthCheckInterval = 5 * time.Second
+	defaultHealthCheckTimeout  = 2 * time.Second
+)
+
+var (
+	errHealthTimeoutTooLong = errors.New("HEALTH_CHECK_TIMEOUT must not exceed HEALTH_CHECK_INTERVAL")
+)
+
+type HealthConfig struct {
+	checkInterval time.Duration
+	checkTimeout  time.Duration
+	shutdownDelay time.Duration
+}
+
+// CheckInterval возвращает период проверки зависимостей.
+func (h *HealthConfig) CheckInterval() time.Duration {
+	return h.checkInterval
+}
+
+// CheckTimeout возвращает срок одной проверки зависимостей.
+func (h *HealthConfig) CheckTimeout() time.Duration {
+	return h.checkTimeout
+}
+
+// ShutdownDelay возвращает паузу между переводом сервисов в NOT_SERVING и остановкой серверов,
+// за которую балансировщики успевают исключить экземпляр; 0 — без паузы.
+func (h *HealthConfig) ShutdownDelay() time.Duration {
+	return h.shutdownDelay
+}
+
+// NewHealthConfig создаёт конфигурацию проверок готовности из переменных окружения HEALTH_*.
+func NewHealthConfig() (*HealthConfig, error) {
+	interval, err := durationFromEnv(envHealthCheckInterval, defaultHealthCheckInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout, err := durationFromEnv(envHealthCheckTimeout, defaultHealthCheckTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout > interval {
+		return nil, errHealthTimeoutTooLong
+	}
+
+	shutdownDelay, err := durationFromEnv(envHealthShutdownDelay, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HealthConfig{
+		checkInterval: interval,
+		checkTimeout:  timeout,
+		shutdownDelay: shutdownDelay,
+	}, nil
+}
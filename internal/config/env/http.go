@@ -0,0 +1,37 @@
+package env
+
+import (
+	"github.com/based-chat/auth/internal/config"
+)
+
+var _ config.HTTPConfig = (*HTTPConfig)(nil)
+
+const (
+	envHTTPHost = "HTTP_HOST"
+	envHTTPPort = "HTTP_PORT"
+
+	defaultHTTPHost = "localhost"
+	defaultHTTPPort = 8080
+)
+
+type HTTPConfig struct {
+	address string
+}
+
+// Address возвращает адрес HTTP-сервера в формате host:port.
+func (h *HTTPConfig) Address() string {
+	return h.address
+}
+
+// NewHTTPConfig создаёт конфигурацию HTTP-сервера из переменных HTTP_HOST и HTTP_PORT.
+// Незаданные переменные заменяются значениями по умолчанию, некорректный порт приводит к ошибке.
+func NewHTTPConfig() (*HTTPConfig, error) {
+	address, err := addressFromEnv(envHTTPHost, envHTTPPort, defaultHTTPHost, defaultHTTPPort)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTTPConfig{
+		address: address,
+	}, nil
+}
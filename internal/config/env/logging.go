@@ -0,0 +1,61 @@
+package env
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/based-chat/auth/internal/config"
+)
+
+var _ config.LoggingConfig = (*LoggingConfig)(nil)
+
+const (
+	envLogLevel  = "LOG_LEVEL"
+	envLogFormat = "LOG_FORMAT"
+
+	defaultLogLevel  = slog.LevelInfo
+	defaultLogFormat = config.LogFormatJSON
+)
+
+type LoggingConfig struct {
+	level  slog.Level
+	format config.LogFormat
+}
+
+// Level возвращает минимальный уровень записей журнала.
+func (l *LoggingConfig) Level() slog.Level {
+	return l.level
+}
+
+// Format возвращает формат записей журнала.
+func (l *LoggingConfig) Format() config.LogFormat {
+	return l.format
+}
+
+// NewLoggingConfig создаёт конфигурацию журнала из переменных LOG_LEVEL (debug, info, warn
+// или error) и LOG_FORMAT (json или text).
+func NewLoggingConfig() (*LoggingConfig, error) {
+	level := defaultLogLevel
+
+	if raw := os.Getenv(envLogLevel); raw != "" {
+		if err := level.UnmarshalText([]byte(raw)); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", errInvalidEnvValue, envLogLevel, err)
+		}
+	}
+
+	format := config.LogFormat(os.Getenv(envLogFormat))
+
+	switch format {
+	case "":
+		format = defaultLogFormat
+	case config.LogFormatJSON, config.LogFormatText:
+	default:
+		return nil, fmt.Errorf("%w: %s: unknown format %q", errInvalidEnvValue, envLogFormat, format)
+	}
+
+	return &LoggingConfig{
+		level:  level,
+		format: format,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package env
+
+import (
+	"time"
+
+	"github.com/based-chat/auth/internal/config"
+)
+
+var _ config.LoginConfig = (*LoginConfig)(nil)
+
+const (
+	envLoginMaxFailedAttempts = "LOGIN_MAX_FAILED_ATTEMPTS"
+	envLoginLockoutDuration   = "LOGIN_LOCKOUT_DURATION"
+
+	defaultLoginMaxFailedAttempts = 5
+	defaultLoginLockoutDuration   = 15 * time.Minute
+)
+
+type LoginConfig struct {
+	maxFailedAttempts int
+	lockoutDuration   time.Duration
+}
+
+// MaxFailedAttempts возвращает число неудачных входов подряд, после которого вход по email блокируется.
+func (l *LoginConfig) MaxFailedAttempts() int {
+	return l.maxFailedAttempts
+}
+
+// LockoutDuration возвращает срок блокировки входа.
+func (l *LoginConfig) LockoutDuration() time.Duration {
+	return l.lockoutDuration
+}
+
+// NewLoginConfig создаёт конфигурацию блокировки входа из переменных окружения LOGIN_*.
+func NewLoginConfig() (*LoginConfig, error) {
+	maxAttempts, err := uintFromEnv(envLoginMaxFailedAttempts, defaultLoginMaxFailedAttempts, 1, 16)
+	if err != nil {
+		return nil, err
+	}
+
+	lockout, err := durationFromEnv(envLoginLockoutDuration, defaultLoginLockoutDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginConfig{
+		maxFailedAttempts: int(maxAttempts),
+		lockoutDuration:   lockout,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package env
+
+import (
+	"github.com/based-chat/auth/internal/config"
+)
+
+var _ config.MetricsConfig = (*MetricsConfig)(nil)
+
+const (
+	envMetricsHost = "METRICS_HOST"
+	envMetricsPort = "METRICS_PORT"
+
+	defaultMetricsHost = "localhost"
+	defaultMetricsPort = 9090
+)
+
+type MetricsConfig struct {
+	address string
+}
+
+// Address возвращает адрес HTTP-сервера метрик в формате host:port.
+func (m *MetricsConfig) Address() string {
+	return m.address
+}
+
+// NewMetricsConfig создаёт конфигурацию сервера метрик из переменных METRICS_HOST и METRICS_PORT.
+// Метрики отдаются отдельным листенером, чтобы их не было видно снаружи вместе с JWKS.
+func NewMetricsConfig() (*MetricsConfig, error) {
+	address, err := addressFromEnv(envMetricsHost, envMetricsPort, defaultMetricsHost, defaultMetricsPort)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricsConfig{
+		address: address,
+	}, nil
+}
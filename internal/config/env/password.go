@@ -0,0 +1,98 @@
+package env
+
+import (
+	"github.com/based-chat/auth/internal/config"
+)
+
+var _ config.PasswordConfig = (*PasswordConfig)(nil)
+
+const (
+	envArgon2Memory      = "PASSWORD_ARGON2_MEMORY"
+	envArgon2Iterations  = "PASSWORD_ARGON2_ITERATIONS"
+	envArgon2Parallelism = "PASSWORD_ARGON2_PARALLELISM"
+	envArgon2SaltLength  = "PASSWORD_ARGON2_SALT_LENGTH"
+	envArgon2KeyLength   = "PASSWORD_ARGON2_KEY_LENGTH"
+
+	// Значения по умолчанию соответствуют рекомендациям OWASP для argon2id.
+	defaultArgon2Memory      = 64 * 1024
+	defaultArgon2Iterations  = 3
+	defaultArgon2Parallelism = 2
+	defaultArgon2SaltLength  = 16
+	defaultArgon2KeyLength   = 32
+
+	// minArgon2Memory — минимум OWASP для argon2id (19 МиБ).
+	minArgon2Memory     = 19 * 1024
+	minArgon2SaltLength = 8
+	minArgon2KeyLength  = 16
+)
+
+type PasswordConfig struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	saltLength  uint32
+	keyLength   uint32
+}
+
+// Argon2Memory возвращает объём памяти argon2id в КиБ.
+func (p *PasswordConfig) Argon2Memory() uint32 {
+	return p.memory
+}
+
+// Argon2Iterations возвращает число проходов argon2id.
+func (p *PasswordConfig) Argon2Iterations() uint32 {
+	return p.iterations
+}
+
+// Argon2Parallelism возвращает число потоков argon2id.
+func (p *PasswordConfig) Argon2Parallelism() uint8 {
+	return p.parallelism
+}
+
+// Argon2SaltLength возвращает длину соли в байтах.
+func (p *PasswordConfig) Argon2SaltLength() uint32 {
+	return p.saltLength
+}
+
+// Argon2KeyLength возвращает длину вычисляемого ключа в байтах.
+func (p *PasswordConfig) Argon2KeyLength() uint32 {
+	return p.keyLength
+}
+
+// NewPasswordConfig создаёт конфигурацию хеширования паролей из переменных окружения
+// PASSWORD_ARGON2_*. Незаданные переменные заменяются значениями по умолчанию,
+// некорректные или слишком слабые значения приводят к ошибке.
+func NewPasswordConfig() (*PasswordConfig, error) {
+	memory, err := uintFromEnv(envArgon2Memory, defaultArgon2Memory, minArgon2Memory, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	iterations, err := uintFromEnv(envArgon2Iterations, defaultArgon2Iterations, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	parallelism, err := uintFromEnv(envArgon2Parallelism, defaultArgon2Parallelism, 1, 8)
+	if err != nil {
+		return nil, err
+	}
+
+	saltLength, err := uintFromEnv(envArgon2SaltLength, defaultArgon2SaltLength, minArgon2SaltLength, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	keyLength, err := uintFromEnv(envArgon2KeyLength, defaultArgon2KeyLength, minArgon2KeyLength, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordConfig{
+		memory:      uint32(memory),
+		iterations:  uint32(iterations),
+		parallelism: uint8(parallelism),
+		saltLength:  uint32(saltLength),
+		keyLength:   uint32(keyLength),
+	}, nil
+}
@@ -2,8 +2,9 @@ package env
 
 import (
 	"errors"
-	"log"
-	"os"
+	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/based-chat/auth/internal/config"
 )
@@ -11,7 +12,19 @@ import (
 var _ config.PostgresConfig = (*PostgresConfig)(nil)
 
 const (
-	envPostgresDSN = "POSTGRES_DSN"
+	envPostgresDSN              = "POSTGRES_DSN"
+	envPostgresMaxConns         = "POSTGRES_MAX_CONNS"
+	envPostgresMinConns         = "POSTGRES_MIN_CONNS"
+	envPostgresMaxConnLifetime  = "POSTGRES_MAX_CONN_LIFETIME"
+	envPostgresMaxConnIdleTime  = "POSTGRES_MAX_CONN_IDLE_TIME"
+	envPostgresStatementTimeout = "POSTGRES_STATEMENT_TIMEOUT"
+	envPostgresTxMaxRetries     = "POSTGRES_TX_MAX_RETRIES"
+
+	defaultPostgresMaxConns        = 10
+	defaultPostgresMinConns        = 0
+	defaultPostgresMaxConnLifetime = time.Hour
+	defaultPostgresMaxConnIdleTime = 30 * time.Minute
+	defaultPostgresTxMaxRetries    = 3
 )
 
 var (
@@ -19,26 +32,113 @@ var (
 )
 
 type PostgresConfig struct {
-	dsn string
+	dsn              *Secret
+	maxConns         int32
+	minConns         int32
+	maxConnLifetime  time.Duration
+	maxConnIdleTime  time.Duration
+	statementTimeout time.Duration
+	txMaxRetries     int
 }
 
-// DSN возвращает строку подключения к PostgreSQL.
+// DSN возвращает текущую строку подключения к PostgreSQL.
 func (p *PostgresConfig) DSN() string {
+	return p.dsn.Value()
+}
+
+// DSNSecret возвращает секрет со строкой подключения, чтобы следить за его файлом.
+func (p *PostgresConfig) DSNSecret() *Secret {
 	return p.dsn
 }
 
+// MaxConns возвращает наибольшее число соединений в пуле.
+func (p *PostgresConfig) MaxConns() int32 {
+	return p.maxConns
+}
+
+// MinConns возвращает число соединений, которые пул держит открытыми даже без нагрузки.
+func (p *PostgresConfig) MinConns() int32 {
+	return p.minConns
+}
+
+// MaxConnLifetime возвращает срок, после которого соединение закрывается и заменяется новым.
+func (p *PostgresConfig) MaxConnLifetime() time.Duration {
+	return p.maxConnLifetime
+}
+
+// MaxConnIdleTime возвращает срок, после которого простаивающее соединение закрывается.
+func (p *PostgresConfig) MaxConnIdleTime() time.Duration {
+	return p.maxConnIdleTime
+}
+
+// StatementTimeout возвращает ограничение на время выполнения одного запроса; 0 — без ограничения.
+func (p *PostgresConfig) StatementTimeout() time.Duration {
+	return p.statementTimeout
+}
+
+// TxMaxRetries возвращает, сколько раз транзакция повторяется после конфликта сериализации.
+func (p *PostgresConfig) TxMaxRetries() int {
+	return p.txMaxRetries
+}
+
 // NewPostgresConfig создаёт и возвращает конфигурацию PostgreSQL.
-// DSN читается из переменной окружения POSTGRES_DSN; если переменная не задана или пустая,
-// выводится предупреждение в лог.
-// Возвращает указатель на PostgresConfig и ошибку (в текущей реализации всегда nil).
+// DSN читается из переменной окружения POSTGRES_DSN или из файла, путь к которому задан
+// в POSTGRES_DSN_FILE; если DSN не задан, выводится предупреждение в лог и возвращается ошибка.
+// Параметры пула соединений читаются из переменных POSTGRES_MAX_CONNS, POSTGRES_MIN_CONNS,
+// POSTGRES_MAX_CONN_LIFETIME, POSTGRES_MAX_CONN_IDLE_TIME и POSTGRES_STATEMENT_TIMEOUT,
+// число повторов транзакций — из POSTGRES_TX_MAX_RETRIES.
 func NewPostgresConfig() (*PostgresConfig, error) {
-	dsn := os.Getenv(envPostgresDSN)
-	if dsn == "" {
-		log.Default().Println("postgres dsn is not set")
+	dsn, err := secretFromEnv(envPostgresDSN)
+	if err != nil {
+		return nil, err
+	}
+
+	if dsn.Value() == "" {
+		slog.Warn("postgres dsn is not set")
 		return nil, errPostgresDSNNotSet
 	}
 
+	maxConns, err := uintFromEnv(envPostgresMaxConns, defaultPostgresMaxConns, 1, 31)
+	if err != nil {
+		return nil, err
+	}
+
+	minConns, err := uintFromEnv(envPostgresMinConns, defaultPostgresMinConns, 0, 31)
+	if err != nil {
+		return nil, err
+	}
+
+	if minConns > maxConns {
+		return nil, fmt.Errorf("%w: %s must not exceed %s", errInvalidEnvValue, envPostgresMinConns, envPostgresMaxConns)
+	}
+
+	maxConnLifetime, err := durationFromEnv(envPostgresMaxConnLifetime, defaultPostgresMaxConnLifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	maxConnIdleTime, err := durationFromEnv(envPostgresMaxConnIdleTime, defaultPostgresMaxConnIdleTime)
+	if err != nil {
+		return nil, err
+	}
+
+	statementTimeout, err := durationFromEnv(envPostgresStatementTimeout, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	txMaxRetries, err := uintFromEnv(envPostgresTxMaxRetries, defaultPostgresTxMaxRetries, 0, 8)
+	if err != nil {
+		return nil, err
+	}
+
 	return &PostgresConfig{
-		dsn: dsn,
+		dsn:              dsn,
+		maxConns:         int32(maxConns),
+		minConns:         int32(minConns),
+		maxConnLifetime:  maxConnLifetime,
+		maxConnIdleTime:  maxConnIdleTime,
+		statementTimeout: statementTimeout,
+		txMaxRetries:     int(txMaxRetries),
 	}, nil
 }
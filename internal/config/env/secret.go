@@ -0,0 +1,95 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// fileSuffix — суффикс переменной, в которой вместо самого секрета передаётся путь к файлу
+// с ним, как это делают секреты Docker и Kubernetes.
+const fileSuffix = "_FILE"
+
+var (
+	errSecretConflict   = errors.New("secret is set both directly and via file")
+	errFailedReadSecret = errors.New("failed to read secret file")
+)
+
+// Secret — секрет, заданный переменной окружения NAME или файлом из переменной NAME_FILE.
+// Секрет из файла перечитывается методом Reload, поэтому ротация файла не требует перезапуска.
+type Secret struct {
+	mu    sync.RWMutex
+	name  string
+	path  string
+	value string
+}
+
+// Name возвращает имя переменной окружения секрета.
+func (s *Secret) Name() string {
+	return s.name
+}
+
+// Path возвращает путь к файлу секрета или пустую строку, если секрет задан напрямую.
+func (s *Secret) Path() string {
+	return s.path
+}
+
+// Value возвращает текущее значение секрета.
+func (s *Secret) Value() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.value
+}
+
+// Reload перечитывает файл секрета. Секрет, заданный напрямую, не меняется.
+func (s *Secret) Reload() error {
+	if s.path == "" {
+		return nil
+	}
+
+	value, err := readSecretFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.value = value
+	s.mu.Unlock()
+
+	return nil
+}
+
+// secretFromEnv читает секрет из переменной name или из файла, путь к которому задан
+// в переменной name_FILE. Одновременное использование обеих переменных считается ошибкой.
+func secretFromEnv(name string) (*Secret, error) {
+	value := os.Getenv(name)
+	path := os.Getenv(name + fileSuffix)
+
+	if path == "" {
+		return &Secret{name: name, value: value}, nil
+	}
+
+	if value != "" {
+		return nil, fmt.Errorf("%w: %s and %s", errSecretConflict, name, name+fileSuffix)
+	}
+
+	secret := &Secret{name: name, path: path}
+	if err := secret.Reload(); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// readSecretFile читает файл секрета, отбрасывая завершающий перевод строки.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errFailedReadSecret, err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
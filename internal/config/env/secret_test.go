@@ -0,0 +1,119 @@
+package env_test
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/based-chat/auth/internal/config/env"
+)
+
+func TestSecretFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "page_token_key")
+	writeFile(t, path, "from file\n")
+
+	tests := []struct {
+		name      string
+		value     string
+		path      string
+		want      string
+		wantPath  string
+		wantError bool
+	}{
+		{name: "unset", want: ""},
+		{name: "value", value: "direct", want: "direct"},
+		{name: "file without trailing newline", path: path, want: "from file", wantPath: path},
+		{name: "value and file", value: "direct", path: path, wantError: true},
+		{name: "missing file", path: filepath.Join(dir, "missing"), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("USER_PAGE_TOKEN_KEY", tt.value)
+			t.Setenv("USER_PAGE_TOKEN_KEY_FILE", tt.path)
+
+			cfg, err := env.NewUserConfig()
+			if tt.wantError {
+				if err == nil {
+					t.Fatal("NewUserConfig() error = nil, want an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewUserConfig() error = %v", err)
+			}
+
+			if got := cfg.PageTokenKey(); got != tt.want {
+				t.Errorf("PageTokenKey() = %q, want %q", got, tt.want)
+			}
+
+			if got := cfg.PageTokenKeySecret().Path(); got != tt.wantPath {
+				t.Errorf("Path() = %q, want %q", got, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestSecretReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "postgres_dsn")
+	writeFile(t, path, "postgres://old")
+
+	t.Setenv("POSTGRES_DSN", "")
+	t.Setenv("POSTGRES_DSN_FILE", path)
+
+	cfg, err := env.NewPostgresConfig()
+	if err != nil {
+		t.Fatalf("NewPostgresConfig() error = %v", err)
+	}
+
+	writeFile(t, path, "postgres://new\n")
+
+	if err := cfg.DSNSecret().Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	if got := cfg.DSN(); got != "postgres://new" {
+		t.Errorf("DSN() after Reload() = %q, want %q", got, "postgres://new")
+	}
+}
+
+func TestConfigSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "postgres_dsn")
+	writeFile(t, path, "postgres://localhost/auth")
+
+	tests := []struct {
+		name   string
+		dsn    string
+		path   string
+		memory bool
+		want   []string
+	}{
+		{name: "dsn from file", path: path, want: []string{path}},
+		{name: "dsn from value", dsn: "postgres://localhost/auth"},
+		{name: "memory storage", path: path, memory: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("POSTGRES_DSN", tt.dsn)
+			t.Setenv("POSTGRES_DSN_FILE", tt.path)
+
+			cfg, err := env.NewConfig(!tt.memory)
+			if err != nil {
+				t.Fatalf("NewConfig() error = %v", err)
+			}
+
+			var got []string
+			for _, secret := range cfg.Secrets() {
+				got = append(got, secret.Path())
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Secrets() paths = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
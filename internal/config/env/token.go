@@ -0,0 +1,126 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/based-chat/auth/internal/config"
+)
+
+var _ config.TokenConfig = (*TokenConfig)(nil)
+
+const (
+	envTokenIssuer               = "TOKEN_ISSUER"
+	envTokenAudience             = "TOKEN_AUDIENCE"
+	envTokenAccessTTL            = "TOKEN_ACCESS_TTL"
+	envTokenRefreshTTL           = "TOKEN_REFRESH_TTL"
+	envTokenRefreshRetention     = "TOKEN_REFRESH_RETENTION"
+	envTokenSigningKeyFile       = "TOKEN_SIGNING_KEY_FILE"
+	envTokenVerificationKeyFiles = "TOKEN_VERIFICATION_KEY_FILES"
+
+	defaultTokenIssuer     = "based-chat-auth"
+	defaultTokenAudience   = "based-chat"
+	defaultTokenAccessTTL  = 15 * time.Minute
+	defaultTokenRefreshTTL = 30 * 24 * time.Hour
+	// defaultTokenRefreshRetention — срок, который истёкшее семейство refresh-токенов хранится
+	// до удаления.
+	defaultTokenRefreshRetention = 7 * 24 * time.Hour
+)
+
+var (
+	errTokenTTLMismatch = errors.New("refresh token ttl must be greater than access token ttl")
+)
+
+type TokenConfig struct {
+	issuer     string
+	audience   string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	retention  time.Duration
+
+	signingKeyFile       string
+	verificationKeyFiles []string
+}
+
+// Issuer возвращает значение claim iss выдаваемых токенов.
+func (t *TokenConfig) Issuer() string {
+	return t.issuer
+}
+
+// Audience возвращает значение claim aud выдаваемых токенов.
+func (t *TokenConfig) Audience() string {
+	return t.audience
+}
+
+// AccessTokenTTL возвращает время жизни access-токена.
+func (t *TokenConfig) AccessTokenTTL() time.Duration {
+	return t.accessTTL
+}
+
+// RefreshTokenTTL возвращает время жизни refresh-токена.
+func (t *TokenConfig) RefreshTokenTTL() time.Duration {
+	return t.refreshTTL
+}
+
+// RefreshTokenRetention возвращает срок, после которого семейство refresh-токенов, все токены
+// которого истекли, удаляется из хранилища.
+func (t *TokenConfig) RefreshTokenRetention() time.Duration {
+	return t.retention
+}
+
+// SigningKeyFile возвращает путь к PEM-файлу активного ключа подписи.
+// Пустая строка означает, что ключ генерируется в памяти при каждом запуске.
+func (t *TokenConfig) SigningKeyFile() string {
+	return t.signingKeyFile
+}
+
+// VerificationKeyFiles возвращает пути к PEM-файлам выведенных из оборота ключей,
+// подписи которых ещё принимаются.
+func (t *TokenConfig) VerificationKeyFiles() []string {
+	return t.verificationKeyFiles
+}
+
+// NewTokenConfig создаёт конфигурацию токенов из переменных окружения TOKEN_*.
+// TOKEN_VERIFICATION_KEY_FILES — список путей через запятую.
+func NewTokenConfig() (*TokenConfig, error) {
+	issuer := os.Getenv(envTokenIssuer)
+	if issuer == "" {
+		issuer = defaultTokenIssuer
+	}
+
+	audience := os.Getenv(envTokenAudience)
+	if audience == "" {
+		audience = defaultTokenAudience
+	}
+
+	accessTTL, err := durationFromEnv(envTokenAccessTTL, defaultTokenAccessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTTL, err := durationFromEnv(envTokenRefreshTTL, defaultTokenRefreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	retention, err := durationFromEnv(envTokenRefreshRetention, defaultTokenRefreshRetention)
+	if err != nil {
+		return nil, err
+	}
+
+	if refreshTTL <= accessTTL {
+		return nil, errTokenTTLMismatch
+	}
+
+	return &TokenConfig{
+		issuer:     issuer,
+		audience:   audience,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+		retention:  retention,
+
+		signingKeyFile:       os.Getenv(envTokenSigningKeyFile),
+		verificationKeyFiles: listFromEnv(envTokenVerificationKeyFiles),
+	}, nil
+}
@@ -0,0 +1,127 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/based-chat/auth/internal/config"
+)
+
+var _ config.TracingConfig = (*TracingConfig)(nil)
+
+const (
+	envTracingExporter     = "TRACING_EXPORTER"
+	envTracingOTLPEndpoint = "TRACING_OTLP_ENDPOINT"
+	envTracingOTLPInsecure = "TRACING_OTLP_INSECURE"
+	envTracingFile         = "TRACING_FILE"
+	envTracingSampleRatio  = "TRACING_SAMPLE_RATIO"
+
+	defaultTracingExporter     = config.TracingExporterNone
+	defaultTracingOTLPEndpoint = "localhost:4317"
+	defaultTracingSampleRatio  = 1.0
+)
+
+var (
+	errTracingFileRequired = errors.New("TRACING_FILE is required for TRACING_EXPORTER=file")
+)
+
+// tracingExporters — допустимые значения TRACING_EXPORTER.
+var tracingExporters = map[config.TracingExporter]struct{}{
+	config.TracingExporterNone:   {},
+	config.TracingExporterOTLP:   {},
+	config.TracingExporterStdout: {},
+	config.TracingExporterFile:   {},
+}
+
+type TracingConfig struct {
+	exporter     config.TracingExporter
+	otlpEndpoint string
+	otlpInsecure bool
+	file         string
+	sampleRatio  float64
+}
+
+// Exporter возвращает способ экспорта трассировок.
+func (t *TracingConfig) Exporter() config.TracingExporter {
+	return t.exporter
+}
+
+// OTLPEndpoint возвращает адрес коллектора OTLP/gRPC в формате host:port.
+func (t *TracingConfig) OTLPEndpoint() string {
+	return t.otlpEndpoint
+}
+
+// OTLPInsecure сообщает, подключаться ли к коллектору без TLS.
+func (t *TracingConfig) OTLPInsecure() bool {
+	return t.otlpInsecure
+}
+
+// File возвращает путь к файлу, в который дописываются трассировки.
+func (t *TracingConfig) File() string {
+	return t.file
+}
+
+// SampleRatio возвращает долю трассировок, начатых этим сервисом, которые записываются.
+// Для входящих запросов решение о записи наследуется от вызывающего.
+func (t *TracingConfig) SampleRatio() float64 {
+	return t.sampleRatio
+}
+
+// NewTracingConfig создаёт конфигурацию трассировки из переменных окружения TRACING_*.
+// По умолчанию трассировка выключена.
+func NewTracingConfig() (*TracingConfig, error) {
+	exporter := config.TracingExporter(os.Getenv(envTracingExporter))
+	if exporter == "" {
+		exporter = defaultTracingExporter
+	}
+
+	if _, ok := tracingExporters[exporter]; !ok {
+		return nil, fmt.Errorf("%w: %s: unknown exporter %q", errInvalidEnvValue, envTracingExporter, exporter)
+	}
+
+	endpoint := os.Getenv(envTracingOTLPEndpoint)
+	if endpoint == "" {
+		endpoint = defaultTracingOTLPEndpoint
+	}
+
+	var insecure bool
+
+	if raw := os.Getenv(envTracingOTLPInsecure); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", errInvalidEnvValue, envTracingOTLPInsecure, err)
+		}
+
+		insecure = value
+	}
+
+	file := os.Getenv(envTracingFile)
+	if exporter == config.TracingExporterFile && file == "" {
+		return nil, errTracingFileRequired
+	}
+
+	sampleRatio := defaultTracingSampleRatio
+
+	if raw := os.Getenv(envTracingSampleRatio); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", errInvalidEnvValue, envTracingSampleRatio, err)
+		}
+
+		if value < 0 || value > 1 {
+			return nil, fmt.Errorf("%w: %s must be between 0 and 1", errInvalidEnvValue, envTracingSampleRatio)
+		}
+
+		sampleRatio = value
+	}
+
+	return &TracingConfig{
+		exporter:     exporter,
+		otlpEndpoint: endpoint,
+		otlpInsecure: insecure,
+		file:         file,
+		sampleRatio:  sampleRatio,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package env
+
+import (
+	"github.com/based-chat/auth/internal/config"
+)
+
+var _ config.UserConfig = (*UserConfig)(nil)
+
+const (
+	envUserGetManyMaxIDs = "USER_GET_MANY_MAX_IDS"
+	envUserPageTokenKey  = "USER_PAGE_TOKEN_KEY"
+
+	defaultUserGetManyMaxIDs = 500
+)
+
+type UserConfig struct {
+	getManyMaxIDs int
+	pageTokenKey  *Secret
+}
+
+// GetManyMaxIDs возвращает наибольшее число ID в одном запросе UserV1.GetMany.
+func (u *UserConfig) GetManyMaxIDs() int {
+	return u.getManyMaxIDs
+}
+
+// PageTokenKey возвращает секрет, которым шифруются токены страниц UserV1.List. Ключ читается
+// только при запуске: его смена делает выданные токены недействительными. Пустое значение
+// означает, что ключ генерируется при каждом запуске.
+func (u *UserConfig) PageTokenKey() string {
+	return u.pageTokenKey.Value()
+}
+
+// PageTokenKeySecret возвращает секрет ключа токенов страниц.
+func (u *UserConfig) PageTokenKeySecret() *Secret {
+	return u.pageTokenKey
+}
+
+// NewUserConfig создаёт конфигурацию сервиса пользователей из переменных окружения USER_*.
+// Ключ токенов страниц читается из USER_PAGE_TOKEN_KEY или из файла в USER_PAGE_TOKEN_KEY_FILE.
+func NewUserConfig() (*UserConfig, error) {
+	maxIDs, err := uintFromEnv(envUserGetManyMaxIDs, defaultUserGetManyMaxIDs, 1, 16)
+	if err != nil {
+		return nil, err
+	}
+
+	pageTokenKey, err := secretFromEnv(envUserPageTokenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserConfig{
+		getManyMaxIDs: int(maxIDs),
+		pageTokenKey:  pageTokenKey,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package env
+
+import (
+	"context"
+	"crypto/sha256"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileWatcher периодически проверяет содержимое файлов и вызывает обработчик, когда оно
+// меняется. Сравнивается хеш содержимого, а не время изменения: Kubernetes обновляет
+// смонтированные секреты подменой символической ссылки.
+type FileWatcher struct {
+	interval time.Duration
+
+	mu      sync.Mutex
+	watches []*watch
+}
+
+type watch struct {
+	path     string
+	sum      [sha256.Size]byte
+	onChange func() error
+	// failed — хеш содержимого, которое onChange не смог применить; пока файл не изменится
+	// снова, повторных попыток и записей в журнал нет.
+	failed [sha256.Size]byte
+}
+
+// NewFileWatcher создаёт наблюдатель, проверяющий файлы раз в interval.
+func NewFileWatcher(interval time.Duration) *FileWatcher {
+	return &FileWatcher{interval: interval}
+}
+
+// Add начинает следить за файлом path. Пустой path игнорируется.
+// Ошибка onChange логируется, а повторная попытка произойдёт при следующем изменении файла.
+func (w *FileWatcher) Add(path string, onChange func() error) {
+	if path == "" {
+		return
+	}
+
+	sum, _ := fileSum(path)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.watches = append(w.watches, &watch{path: path, sum: sum, onChange: onChange})
+}
+
+// Run проверяет файлы, пока не отменён ctx.
+func (w *FileWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// check вызывает обработчики изменившихся файлов.
+func (w *FileWatcher) check() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, watch := range w.watches {
+		sum, err := fileSum(watch.path)
+		if err != nil {
+			slog.Error("failed to check file", slog.String("path", watch.path), slog.Any("error", err))
+			continue
+		}
+
+		if sum == watch.sum || sum == watch.failed {
+			continue
+		}
+
+		if err := watch.onChange(); err != nil {
+			watch.failed = sum
+			slog.Error("failed to reload file", slog.String("path", watch.path), slog.Any("error", err))
+
+			continue
+		}
+
+		watch.sum = sum
+		watch.failed = [sha256.Size]byte{}
+		slog.Info("reloaded file", slog.String("path", watch.path))
+	}
+}
+
+// fileSum возвращает хеш содержимого файла.
+func fileSum(path string) ([sha256.Size]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+
+	return sha256.Sum256(data), nil
+}
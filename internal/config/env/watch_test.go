@@ -0,0 +1,142 @@
+package env_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/based-chat/auth/internal/config/env"
+)
+
+// watchInterval — период проверки файлов в тестах наблюдателя.
+const watchInterval = time.Millisecond
+
+// writeFile атомарно заменяет файл path файлом с содержимым content, чтобы наблюдатель
+// не прочитал его наполовину записанным.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", tmp, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatalf("rename %s: %v", tmp, err)
+	}
+}
+
+// waitFor ждёт, пока cond не станет истинным, и проваливает тест через секунду ожидания.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+
+		time.Sleep(watchInterval)
+	}
+}
+
+// runWatcher запускает наблюдатель до завершения теста.
+func runWatcher(t *testing.T, watcher *env.FileWatcher) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		watcher.Run(ctx)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestFileWatcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	writeFile(t, path, "v1")
+
+	var calls atomic.Int32
+
+	watcher := env.NewFileWatcher(watchInterval)
+	watcher.Add(path, func() error {
+		calls.Add(1)
+		return nil
+	})
+	watcher.Add("", func() error {
+		t.Error("onChange called for an empty path")
+		return nil
+	})
+	runWatcher(t, watcher)
+
+	// Без изменений обработчик не вызывается.
+	time.Sleep(10 * watchInterval)
+
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("onChange called %d times before the file changed, want 0", got)
+	}
+
+	writeFile(t, path, "v2")
+	waitFor(t, "the first reload", func() bool { return calls.Load() == 1 })
+
+	// Тот же файл с тем же содержимым повторно не применяется.
+	writeFile(t, path, "v2")
+	time.Sleep(10 * watchInterval)
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("onChange called %d times for unchanged content, want 1", got)
+	}
+}
+
+func TestFileWatcherFailedReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	writeFile(t, path, "v1")
+
+	var (
+		calls atomic.Int32
+		fail  atomic.Bool
+	)
+
+	fail.Store(true)
+
+	watcher := env.NewFileWatcher(watchInterval)
+	watcher.Add(path, func() error {
+		calls.Add(1)
+
+		if fail.Load() {
+			return errors.New("invalid content")
+		}
+
+		return nil
+	})
+	runWatcher(t, watcher)
+
+	writeFile(t, path, "broken")
+	waitFor(t, "the failed reload", func() bool { return calls.Load() == 1 })
+
+	// Неудачное содержимое не перечитывается на каждом такте.
+	time.Sleep(10 * watchInterval)
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("onChange called %d times for the same failed content, want 1", got)
+	}
+
+	// Следующее изменение файла пробуется снова.
+	fail.Store(false)
+	writeFile(t, path, "fixed")
+	waitFor(t, "the retry after a change", func() bool { return calls.Load() == 2 })
+
+	// Возврат к содержимому, которое не удалось применить, — тоже изменение.
+	writeFile(t, path, "broken")
+	waitFor(t, "the reload of previously failed content", func() bool { return calls.Load() == 3 })
+}
@@ -0,0 +1,222 @@
+// Package config describes the service configuration and assembles it from layered sources.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Layer — источник значения параметра конфигурации.
+type Layer string
+
+// Слои перечислены в порядке возрастания приоритета: значение из более позднего слоя
+// перекрывает значение из более раннего, а незаданный параметр получает значение по умолчанию.
+const (
+	LayerFile   Layer = "file"
+	LayerDotEnv Layer = "dotenv"
+	LayerEnv    Layer = "env"
+	LayerFlag   Layer = "flag"
+)
+
+var (
+	errFailedReadFile      = errors.New("failed to read config file")
+	errFailedReadDotEnv    = errors.New("failed to read env file")
+	errUnsupportedFormat   = errors.New("unsupported config file format")
+	errInvalidOverride     = errors.New("override must look like KEY=VALUE")
+	errFailedApplySettings = errors.New("failed to apply config")
+)
+
+// Options задаёт источники конфигурации.
+type Options struct {
+	// File — путь к YAML- или TOML-файлу; пустая строка означает, что файла нет.
+	File string
+	// DotEnvFile — путь к файлу с переменными окружения. Отсутствие файла не считается ошибкой.
+	DotEnvFile string
+	// Overrides — значения из флагов командной строки.
+	Overrides Overrides
+}
+
+// Sources сопоставляет имени параметра слой, из которого взято его значение.
+type Sources map[string]Layer
+
+// Load собирает конфигурацию из файла, .env, окружения процесса и флагов и публикует
+// итоговые значения в окружении процесса, откуда их читают конструкторы пакета env.
+//
+// Параметры именуются как переменные окружения. Во вложенных секциях файла имена
+// склеиваются через подчёркивание и переводятся в верхний регистр: ключ max_conns секции
+// postgres становится POSTGRES_MAX_CONNS, а списки записываются через запятую.
+func Load(opts Options) (Sources, error) {
+	sources := make(Sources)
+	values := make(map[string]string)
+
+	if opts.File != "" {
+		fileValues, err := readFile(opts.File)
+		if err != nil {
+			return nil, err
+		}
+
+		merge(values, sources, fileValues, LayerFile)
+	}
+
+	if opts.DotEnvFile != "" {
+		dotEnvValues, err := godotenv.Read(opts.DotEnvFile)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %w", errFailedReadDotEnv, err)
+		}
+
+		merge(values, sources, dotEnvValues, LayerDotEnv)
+	}
+
+	for name, value := range values {
+		if _, ok := os.LookupEnv(name); ok {
+			sources[name] = LayerEnv
+			continue
+		}
+
+		if err := os.Setenv(name, value); err != nil {
+			return nil, fmt.Errorf("%w: %w", errFailedApplySettings, err)
+		}
+	}
+
+	for name, value := range opts.Overrides {
+		if err := os.Setenv(name, value); err != nil {
+			return nil, fmt.Errorf("%w: %w", errFailedApplySettings, err)
+		}
+
+		sources[name] = LayerFlag
+	}
+
+	return sources, nil
+}
+
+// Source возвращает слой, из которого взят параметр name, или пустую строку для
+// параметров, заданных только окружением процесса либо значением по умолчанию.
+func (s Sources) Source(name string) Layer {
+	if layer, ok := s[name]; ok {
+		return layer
+	}
+
+	if _, ok := os.LookupEnv(name); ok {
+		return LayerEnv
+	}
+
+	return ""
+}
+
+// Overrides — значения параметров, переданные флагом `--set KEY=VALUE`. Флаг можно повторять.
+type Overrides map[string]string
+
+// String возвращает переопределения в виде KEY=VALUE через запятую.
+func (o Overrides) String() string {
+	pairs := make([]string, 0, len(o))
+	for name, value := range o {
+		pairs = append(pairs, name+"="+value)
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// Set разбирает одно переопределение KEY=VALUE.
+func (o Overrides) Set(raw string) error {
+	name, value, ok := strings.Cut(raw, "=")
+
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return fmt.Errorf("%w: %q", errInvalidOverride, raw)
+	}
+
+	o[strings.ToUpper(name)] = value
+
+	return nil
+}
+
+// merge переносит значения слоя layer в values, перекрывая значения предыдущих слоёв.
+func merge(values map[string]string, sources Sources, layerValues map[string]string, layer Layer) {
+	for name, value := range layerValues {
+		values[name] = value
+		sources[name] = layer
+	}
+}
+
+// readFile читает YAML- или TOML-файл конфигурации, определяя формат по расширению.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedReadFile, err)
+	}
+
+	tree := make(map[string]any)
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedFormat, ext)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errFailedReadFile, path, err)
+	}
+
+	values := make(map[string]string)
+	flatten(values, "", tree)
+
+	return values, nil
+}
+
+// flatten раскладывает дерево параметров файла в плоский набор переменных окружения.
+func flatten(values map[string]string, prefix string, node any) {
+	switch node := node.(type) {
+	case map[string]any:
+		for key, child := range node {
+			flatten(values, join(prefix, key), child)
+		}
+	case []any:
+		items := make([]string, 0, len(node))
+		for _, item := range node {
+			items = append(items, scalar(item))
+		}
+
+		values[prefix] = strings.Join(items, ",")
+	default:
+		values[prefix] = scalar(node)
+	}
+}
+
+// join склеивает имя секции и ключ в имя переменной окружения.
+func join(prefix, key string) string {
+	key = strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(key))
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "_" + key
+}
+
+// scalar приводит значение из файла к строке в том виде, в каком его ожидают конструкторы env.
+func scalar(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,219 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/based-chat/auth/internal/config"
+)
+
+// testVar — параметр, значение которого задаётся в разных слоях.
+const testVar = "AUTH_TEST_VALUE"
+
+// unsetEnv снимает переменные names на время теста и восстанавливает их по его завершении.
+func unsetEnv(t *testing.T, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		t.Setenv(name, "")
+
+		if err := os.Unsetenv(name); err != nil {
+			t.Fatalf("Unsetenv(%q) error = %v", name, err)
+		}
+	}
+}
+
+// writeFile создаёт во временном каталоге файл name с содержимым content и возвращает путь к нему.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		dotEnv     string
+		env        string
+		flag       string
+		want       string
+		wantSource config.Layer
+	}{
+		{name: "default", want: "", wantSource: ""},
+		{name: "file", file: "file", want: "file", wantSource: config.LayerFile},
+		{name: "env file over file", file: "file", dotEnv: "dotenv", want: "dotenv", wantSource: config.LayerDotEnv},
+		{
+			name:       "environment over env file",
+			file:       "file",
+			dotEnv:     "dotenv",
+			env:        "env",
+			want:       "env",
+			wantSource: config.LayerEnv,
+		},
+		{name: "environment only", env: "env", want: "env", wantSource: config.LayerEnv},
+		{
+			name:       "flag over everything",
+			file:       "file",
+			dotEnv:     "dotenv",
+			env:        "env",
+			flag:       "flag",
+			want:       "flag",
+			wantSource: config.LayerFlag,
+		},
+		{name: "flag over file", file: "file", flag: "flag", want: "flag", wantSource: config.LayerFlag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetEnv(t, testVar)
+
+			opts := config.Options{
+				DotEnvFile: filepath.Join(t.TempDir(), "missing.env"),
+				Overrides:  config.Overrides{},
+			}
+
+			if tt.file != "" {
+				opts.File = writeFile(t, "config.yaml", "auth_test_value: "+tt.file+"\n")
+			}
+
+			if tt.dotEnv != "" {
+				opts.DotEnvFile = writeFile(t, ".env", testVar+"="+tt.dotEnv+"\n")
+			}
+
+			if tt.env != "" {
+				t.Setenv(testVar, tt.env)
+			}
+
+			if tt.flag != "" {
+				if err := opts.Overrides.Set(testVar + "=" + tt.flag); err != nil {
+					t.Fatalf("Overrides.Set() error = %v", err)
+				}
+			}
+
+			sources, err := config.Load(opts)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if got := os.Getenv(testVar); got != tt.want {
+				t.Errorf("%s = %q, want %q", testVar, got, tt.want)
+			}
+
+			if got := sources.Source(testVar); got != tt.wantSource {
+				t.Errorf("Source(%s) = %q, want %q", testVar, got, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestLoadFileFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name:    "yaml",
+			file:    "config.yaml",
+			content: "postgres:\n  max-conns: 5\ntoken:\n  verification_key_files: [a.pem, b.pem]\n",
+		},
+		{
+			name:    "toml",
+			file:    "config.toml",
+			content: "[postgres]\nmax_conns = 5\n\n[token]\nverification_key_files = [\"a.pem\", \"b.pem\"]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetEnv(t, "POSTGRES_MAX_CONNS", "TOKEN_VERIFICATION_KEY_FILES")
+
+			if _, err := config.Load(config.Options{File: writeFile(t, tt.file, tt.content)}); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			// Вложенные ключи склеиваются через подчёркивание, списки — через запятую.
+			want := map[string]string{
+				"POSTGRES_MAX_CONNS":           "5",
+				"TOKEN_VERIFICATION_KEY_FILES": "a.pem,b.pem",
+			}
+
+			for name, value := range want {
+				if got := os.Getenv(name); got != value {
+					t.Errorf("%s = %q, want %q", name, got, value)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts func(t *testing.T) config.Options
+	}{
+		{
+			name: "missing config file",
+			opts: func(t *testing.T) config.Options {
+				return config.Options{File: filepath.Join(t.TempDir(), "missing.yaml")}
+			},
+		},
+		{
+			name: "unsupported format",
+			opts: func(t *testing.T) config.Options {
+				return config.Options{File: writeFile(t, "config.json", "{}")}
+			},
+		},
+		{
+			name: "malformed yaml",
+			opts: func(t *testing.T) config.Options {
+				return config.Options{File: writeFile(t, "config.yaml", "postgres: [")}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := config.Load(tt.opts(t)); err == nil {
+				t.Error("Load() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestOverridesSet(t *testing.T) {
+	tests := []struct {
+		raw       string
+		wantName  string
+		wantValue string
+		wantErr   bool
+	}{
+		{raw: "grpc_port=50051", wantName: "GRPC_PORT", wantValue: "50051"},
+		{raw: "LOG_LEVEL=", wantName: "LOG_LEVEL", wantValue: ""},
+		{raw: "POSTGRES_DSN=host=db user=auth", wantName: "POSTGRES_DSN", wantValue: "host=db user=auth"},
+		{raw: "GRPC_PORT", wantErr: true},
+		{raw: "=50051", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			overrides := config.Overrides{}
+
+			err := overrides.Set(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && overrides[tt.wantName] != tt.wantValue {
+				t.Errorf("Set(%q) stored %q = %q, want %q", tt.raw, tt.wantName, overrides[tt.wantName], tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"github.com/based-chat/auth/internal/model"
+
+	srv "github.com/based-chat/auth/pkg/access/v1"
+)
+
+// ToCheckResponseFromDecision преобразует решение о доступе в ответ AccessV1.
+func ToCheckResponseFromDecision(decision *model.AccessDecision) *srv.CheckResponse {
+	return &srv.CheckResponse{
+		Allowed: decision.Allowed,
+		UserId:  decision.UserID,
+		Role:    ToProtoFromRole(decision.Role),
+	}
+}
+
+// ToListRolesResponseFromRoles преобразует роли с разрешениями в ответ AccessV1.
+func ToListRolesResponseFromRoles(roles []*model.RoleInfo) *srv.ListRolesResponse {
+	resp := &srv.ListRolesResponse{Roles: make([]*srv.Role, 0, len(roles))}
+
+	for _, role := range roles {
+		resp.Roles = append(resp.Roles, &srv.Role{
+			Role:        ToProtoFromRole(role.Role),
+			Name:        string(role.Role),
+			Permissions: role.Permissions,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"github.com/based-chat/auth/internal/keys"
+	"github.com/based-chat/auth/internal/model"
+
+	srv "github.com/based-chat/auth/pkg/auth/v1"
+)
+
+// tokenTypeBearer — тип выдаваемых токенов для заголовка Authorization.
+const tokenTypeBearer = "Bearer"
+
+// ToProtoFromTokens преобразует пару токенов в сообщение AuthV1.
+func ToProtoFromTokens(tokens *model.Tokens) *srv.Tokens {
+	return &srv.Tokens{
+		TokenType:             tokenTypeBearer,
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  toTimestamp(tokens.AccessTokenExpiresAt),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: toTimestamp(tokens.RefreshTokenExpiresAt),
+	}
+}
+
+// ToGetJWKSResponseFromJWKS преобразует набор открытых ключей в ответ AuthV1.
+func ToGetJWKSResponseFromJWKS(set keys.JWKS) *srv.GetJWKSResponse {
+	resp := &srv.GetJWKSResponse{Keys: make([]*srv.JsonWebKey, 0, len(set.Keys))}
+
+	for _, key := range set.Keys {
+		resp.Keys = append(resp.Keys, &srv.JsonWebKey{
+			Kty: key.KeyType,
+			Kid: key.KeyID,
+			Use: key.Use,
+			Alg: key.Algorithm,
+			Crv: key.Curve,
+			X:   key.X,
+			N:   key.N,
+			E:   key.E,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,163 @@
+// Package converter maps between protobuf messages and domain models.
+package converter
+
+import (
+	"time"
+
+	"github.com/based-chat/auth/internal/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	srv "github.com/based-chat/auth/pkg/user/v1"
+)
+
+// ToUserFromCreateRequest собирает доменного пользователя из запроса на создание.
+// Пароль в модель не переносится: вызывающая сторона сохраняет в ней только хеш.
+func ToUserFromCreateRequest(req *srv.CreateRequest) *model.User {
+	return &model.User{
+		Name:  req.GetName(),
+		Email: req.GetEmail(),
+		Role:  ToRoleFromProto(req.GetRole()),
+	}
+}
+
+// ToUserUpdateFromRequest собирает набор изменений пользователя из запроса на обновление.
+func ToUserUpdateFromRequest(req *srv.UpdateRequest) *model.UserUpdate {
+	update := &model.UserUpdate{}
+
+	if req.GetName() != nil {
+		name := req.GetName().GetValue()
+		update.Name = &name
+	}
+
+	if req.GetEmail() != nil {
+		email := req.GetEmail().GetValue()
+		update.Email = &email
+	}
+
+	if req.GetRole() != srv.UserRole_UNSPECIFIED {
+		role := ToRoleFromProto(req.GetRole())
+		update.Role = &role
+	}
+
+	return update
+}
+
+// ToGetResponseFromUser преобразует доменного пользователя в ответ UserV1.
+func ToGetResponseFromUser(user *model.User) *srv.GetResponse {
+	return &srv.GetResponse{
+		Id:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      ToProtoFromRole(user.Role),
+		CreatedAt: toTimestamp(user.CreatedAt),
+		UpdatedAt: toTimestamp(user.UpdatedAt),
+	}
+}
+
+// rolesByProto сопоставляет значения proto-перечисления UserRole доменным ролям.
+// Доменная роль совпадает с именем строки в user_role, а её ID — с номером в перечислении:
+// репозиторий переводит роль в ID по таблице соответствия.
+var rolesByProto = map[srv.UserRole]model.Role{
+	srv.UserRole_UNSPECIFIED: model.RoleUnspecified,
+	srv.UserRole_ADMIN:       model.RoleAdmin,
+	srv.UserRole_USER:        model.RoleUser,
+	srv.UserRole_MODERATOR:   model.RoleModerator,
+	srv.UserRole_SUPPORT:     model.RoleSupport,
+}
+
+// sortFieldsByProto сопоставляет значения proto-перечисления SortField полям сортировки.
+var sortFieldsByProto = map[srv.SortField]model.UserSortField{
+	srv.SortField_SORT_FIELD_UNSPECIFIED: model.UserSortByID,
+	srv.SortField_SORT_FIELD_ID:          model.UserSortByID,
+	srv.SortField_SORT_FIELD_CREATED_AT:  model.UserSortByCreatedAt,
+	srv.SortField_SORT_FIELD_NAME:        model.UserSortByName,
+	srv.SortField_SORT_FIELD_EMAIL:       model.UserSortByEmail,
+}
+
+// ToUserListQueryFromRequest собирает запрос страницы пользователей из ListRequest.
+func ToUserListQueryFromRequest(req *srv.ListRequest) *model.UserListQuery {
+	filter := req.GetFilter()
+
+	query := &model.UserListQuery{
+		Filter: model.UserListFilter{
+			EmailPrefix: filter.GetEmailPrefix(),
+			NamePrefix:  filter.GetNamePrefix(),
+		},
+		SortField:  sortFieldsByProto[req.GetSortField()],
+		Descending: req.GetDescending(),
+		Limit:      int(req.GetPageSize()),
+	}
+
+	for _, role := range filter.GetRoles() {
+		query.Filter.Roles = append(query.Filter.Roles, ToRoleFromProto(role))
+	}
+
+	if filter.GetCreatedAfter() != nil {
+		createdAfter := filter.GetCreatedAfter().AsTime()
+		query.Filter.CreatedAfter = &createdAfter
+	}
+
+	if filter.GetCreatedBefore() != nil {
+		createdBefore := filter.GetCreatedBefore().AsTime()
+		query.Filter.CreatedBefore = &createdBefore
+	}
+
+	return query
+}
+
+// ToListResponseFromUsers преобразует страницу пользователей в ответ UserV1.
+func ToListResponseFromUsers(users []*model.User, nextPageToken string) *srv.ListResponse {
+	resp := &srv.ListResponse{
+		Users:         make([]*srv.GetResponse, 0, len(users)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, user := range users {
+		resp.Users = append(resp.Users, ToGetResponseFromUser(user))
+	}
+
+	return resp
+}
+
+// ToGetManyResponseFromUsers преобразует результат пакетного поиска в ответ UserV1.
+func ToGetManyResponseFromUsers(users []*model.User, missing []int64) *srv.GetManyResponse {
+	resp := &srv.GetManyResponse{
+		Users:      make([]*srv.GetResponse, 0, len(users)),
+		MissingIds: missing,
+	}
+
+	for _, user := range users {
+		resp.Users = append(resp.Users, ToGetResponseFromUser(user))
+	}
+
+	return resp
+}
+
+// ToRoleFromProto преобразует роль из proto-перечисления в доменную.
+func ToRoleFromProto(role srv.UserRole) model.Role {
+	if r, ok := rolesByProto[role]; ok {
+		return r
+	}
+
+	return model.RoleUnspecified
+}
+
+// ToProtoFromRole преобразует доменную роль в proto-перечисление.
+func ToProtoFromRole(role model.Role) srv.UserRole {
+	for protoRole, r := range rolesByProto {
+		if r == role {
+			return protoRole
+		}
+	}
+
+	return srv.UserRole_UNSPECIFIED
+}
+
+// toTimestamp возвращает nil для нулевого времени, чтобы не отдавать клиенту 0001-01-01.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,113 @@
+// Package health reports the serving status of the gRPC services through the standard
+// grpc.health.v1 service, based on periodic dependency probes.
+package health
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Probe проверяет зависимость и возвращает ошибку, если она недоступна.
+type Probe func(ctx context.Context) error
+
+// Checker выставляет сервисам статус SERVING, пока проходит проверка probe, и NOT_SERVING,
+// пока она не пройдёт впервые, после сбоя проверки и после Shutdown. Статус пустого имени
+// сервиса описывает сервер целиком.
+type Checker struct {
+	server   *health.Server
+	services []string
+	probe    Probe
+	interval time.Duration
+	timeout  time.Duration
+	serving  bool
+}
+
+// NewChecker создаёт проверку для сервисов services. Проверка probe выполняется раз в interval
+// и должна уложиться в timeout.
+func NewChecker(services []string, probe Probe, interval, timeout time.Duration) *Checker {
+	c := &Checker{
+		server:   health.NewServer(),
+		services: append([]string{""}, services...),
+		probe:    probe,
+		interval: interval,
+		timeout:  timeout,
+	}
+
+	c.set(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return c
+}
+
+// Server возвращает реализацию grpc.health.v1 для регистрации на gRPC-сервере.
+func (c *Checker) Server() *health.Server {
+	return c.server
+}
+
+// Run проверяет зависимости сразу и затем раз в interval, пока не отменён ctx.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown переводит все сервисы в NOT_SERVING до конца работы процесса, чтобы балансировщики
+// перестали направлять новые запросы, пока сервер дорабатывает текущие.
+func (c *Checker) Shutdown() {
+	c.server.Shutdown()
+}
+
+// Drain переводит все сервисы в NOT_SERVING через Shutdown и ждёт delay, чтобы балансировщики
+// успели исключить экземпляр, прежде чем серверы начнут завершать запросы. Ожидание прерывается
+// отменой ctx; тогда возвращается ошибка ctx.
+func (c *Checker) Drain(ctx context.Context, delay time.Duration) error {
+	c.Shutdown()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// check выполняет проверку и обновляет статус, если он изменился.
+func (c *Checker) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	err := c.probe(ctx)
+	if serving := err == nil; serving != c.serving {
+		c.serving = serving
+
+		if serving {
+			slog.InfoContext(ctx, "health: serving")
+			c.set(healthpb.HealthCheckResponse_SERVING)
+		} else {
+			slog.WarnContext(ctx, "health: not serving", slog.Any("error", err))
+			c.set(healthpb.HealthCheckResponse_NOT_SERVING)
+		}
+	}
+}
+
+// set выставляет статус всем сервисам.
+func (c *Checker) set(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,95 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/based-chat/auth/internal/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// drainDelay — пауза Drain в тестах.
+const drainDelay = 50 * time.Millisecond
+
+// servingChecker возвращает проверку сервиса svc, уже выставившую SERVING.
+func servingChecker(t *testing.T) *health.Checker {
+	t.Helper()
+
+	checker := health.NewChecker([]string{"svc"}, func(context.Context) error { return nil }, time.Hour, time.Second)
+
+	// Run проверяет зависимости сразу, а с отменённым контекстом на этом и завершается.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	checker.Run(ctx)
+
+	if got := status(t, checker); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("status before Drain() = %v, want SERVING", got)
+	}
+
+	return checker
+}
+
+// status возвращает статус сервиса svc.
+func status(t *testing.T, checker *health.Checker) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	resp, err := checker.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: "svc"})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	return resp.GetStatus()
+}
+
+func TestCheckerDrain(t *testing.T) {
+	checker := servingChecker(t)
+
+	done := make(chan error, 1)
+	start := time.Now()
+
+	go func() { done <- checker.Drain(context.Background(), drainDelay) }()
+
+	// Статус меняется сразу, а не по истечении паузы.
+	deadline := time.Now().Add(drainDelay / 2)
+	for status(t, checker) != healthpb.HealthCheckResponse_NOT_SERVING {
+		if time.Now().After(deadline) {
+			t.Fatal("status is still SERVING during the drain delay")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("Drain() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < drainDelay {
+		t.Errorf("Drain() returned after %v, want at least %v", elapsed, drainDelay)
+	}
+
+	// Успешная проверка после Drain не возвращает SERVING.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	checker.Run(ctx)
+
+	if got := status(t, checker); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status after a probe = %v, want NOT_SERVING", got)
+	}
+}
+
+func TestCheckerDrainCanceled(t *testing.T) {
+	checker := servingChecker(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := checker.Drain(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("Drain() error = %v, want %v", err, context.Canceled)
+	}
+
+	if got := status(t, checker); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status after Drain() = %v, want NOT_SERVING", got)
+	}
+}
@@ -0,0 +1,62 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+var errPendingMigrations = errors.New("database has pending migrations")
+
+// Pinger — зависимость, доступность которой проверяется запросом Ping, например пул pgxpool.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// MigrationChecker сообщает, остались ли неприменённые миграции.
+type MigrationChecker interface {
+	HasPending(ctx context.Context) (bool, error)
+}
+
+// Ping возвращает проверку, успешную, пока pinger отвечает на Ping.
+func Ping(pinger Pinger) Probe {
+	return pinger.Ping
+}
+
+// Migrations возвращает проверку, успешную, когда все миграции применены. Схема не откатывается
+// во время работы сервера, поэтому после первого успеха проверка к базе больше не обращается.
+func Migrations(checker MigrationChecker) Probe {
+	var verified atomic.Bool
+
+	return func(ctx context.Context) error {
+		if verified.Load() {
+			return nil
+		}
+
+		pending, err := checker.HasPending(ctx)
+		if err != nil {
+			return err
+		}
+
+		if pending {
+			return errPendingMigrations
+		}
+
+		verified.Store(true)
+
+		return nil
+	}
+}
+
+// All возвращает проверку, успешную, только если успешны все probes; они выполняются по порядку.
+func All(probes ...Probe) Probe {
+	return func(ctx context.Context) error {
+		for _, probe := range probes {
+			if err := probe(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/based-chat/auth/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// serverErrorCodes — коды ответа, означающие сбой сервера, а не ошибку клиента;
+// такие вызовы пишутся в журнал с уровнем error.
+var serverErrorCodes = map[codes.Code]bool{
+	codes.Unknown:          true,
+	codes.DeadlineExceeded: true,
+	codes.Unimplemented:    true,
+	codes.Internal:         true,
+	codes.Unavailable:      true,
+	codes.DataLoss:         true,
+}
+
+// AccessLog пишет в журнал по записи на каждый вызов: метод, код ответа и длительность.
+// С уровнем debug в запись добавляются запрос и ответ, чувствительные поля в которых скрыты.
+func AccessLog(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	attrs := accessAttrs(info.FullMethod, err, start)
+
+	if slog.Default().Enabled(ctx, slog.LevelDebug) {
+		if msg, ok := req.(proto.Message); ok {
+			attrs = append(attrs, slog.Any("request", logger.Proto(msg)))
+		}
+
+		if msg, ok := resp.(proto.Message); ok && err == nil {
+			attrs = append(attrs, slog.Any("response", logger.Proto(msg)))
+		}
+	}
+
+	slog.LogAttrs(ctx, accessLevel(err), "rpc", attrs...)
+
+	return resp, err
+}
+
+// AccessLogStream пишет в журнал запись о потоковом вызове после его завершения.
+func AccessLogStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+
+	slog.LogAttrs(ss.Context(), accessLevel(err), "rpc", accessAttrs(info.FullMethod, err, start)...)
+
+	return err
+}
+
+// accessAttrs возвращает общие атрибуты записи о вызове.
+func accessAttrs(method string, err error, start time.Time) []slog.Attr {
+	st := status.Convert(err)
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", st.Code().String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("status_message", st.Message()))
+	}
+
+	return attrs
+}
+
+// accessLevel возвращает уровень записи о вызове, завершившемся ошибкой err.
+func accessLevel(err error) slog.Level {
+	if serverErrorCodes[status.Code(err)] {
+		return slog.LevelError
+	}
+
+	return slog.LevelInfo
+}
@@ -0,0 +1,150 @@
+// Package interceptor provides gRPC server interceptors.
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/based-chat/auth/internal/apperr"
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+
+	errorTokenRequired = "access token is required"
+	errorInvalidToken  = "invalid access token"
+)
+
+type callerKey struct{}
+
+// CallerFromContext возвращает вызывающего пользователя, если запрос был аутентифицирован.
+func CallerFromContext(ctx context.Context) (*model.Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(*model.Caller)
+
+	return caller, ok
+}
+
+// ContextWithCaller кладёт вызывающего пользователя в контекст.
+func ContextWithCaller(ctx context.Context, caller *model.Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// Auth аутентифицирует запросы по bearer-токену из метаданных и применяет правила доступа к методам.
+//
+// Токен в заголовке authorization необязателен только для публичных методов; если он передан,
+// то проверяется всегда. Правило метода получает вызывающего (nil для анонимного вызова
+// публичного метода) и запрос и возвращает gRPC-ошибку, если вызов запрещён.
+type Auth struct {
+	tokens *token.Manager
+	public map[string]bool
+	rules  map[string]Rule
+}
+
+// NewAuth создаёт интерцептор аутентификации с публичными методами public и правилами rules.
+func NewAuth(tokens *token.Manager, public []string, rules map[string]Rule) *Auth {
+	publicSet := make(map[string]bool, len(public))
+	for _, method := range public {
+		publicSet[method] = true
+	}
+
+	return &Auth{
+		tokens: tokens,
+		public: publicSet,
+		rules:  rules,
+	}
+}
+
+// Unary — unary-интерцептор аутентификации и авторизации.
+func (a *Auth) Unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, caller, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule, ok := a.rules[info.FullMethod]; ok {
+		if err := rule(caller, req); err != nil {
+			return nil, err
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+// Stream — stream-интерцептор аутентификации. Правила методов к потокам не применяются,
+// поскольку сообщения потока недоступны до вызова обработчика.
+func (a *Auth) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, _, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate проверяет bearer-токен и возвращает контекст с вызывающим пользователем.
+func (a *Auth) authenticate(ctx context.Context, method string) (context.Context, *model.Caller, error) {
+	raw := bearerToken(ctx)
+	if raw == "" {
+		if a.public[method] {
+			return ctx, nil, nil
+		}
+
+		return nil, nil, apperr.New(codes.Unauthenticated, apperr.ReasonAccessTokenRequired, errorTokenRequired)
+	}
+
+	claims, err := a.tokens.ParseAccessToken(raw)
+	if err != nil {
+		return nil, nil, apperr.New(codes.Unauthenticated, apperr.ReasonInvalidAccessToken, errorInvalidToken)
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, nil, apperr.New(codes.Unauthenticated, apperr.ReasonInvalidAccessToken, errorInvalidToken)
+	}
+
+	caller := &model.Caller{
+		UserID: userID,
+		Role:   claims.Role,
+	}
+
+	return ContextWithCaller(ctx, caller), caller, nil
+}
+
+// bearerToken извлекает токен из заголовка "authorization: Bearer <token>".
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, value := range md.Get(authorizationHeader) {
+		if len(value) > len(bearerPrefix) && strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+			return strings.TrimSpace(value[len(bearerPrefix):])
+		}
+	}
+
+	return ""
+}
+
+// serverStream подменяет контекст потока контекстом с вызывающим пользователем.
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+// Context возвращает контекст потока.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,118 @@
+package interceptor
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/based-chat/auth/internal/apperr"
+	"github.com/based-chat/auth/internal/certs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	errorPrincipalUnknown   = "client certificate is not authorized"
+	errorPrincipalForbidden = "this service may not call the method"
+)
+
+type principalKey struct{}
+
+// PrincipalFromContext возвращает имя сервиса, предъявившего клиентский сертификат.
+func PrincipalFromContext(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalKey{}).(string)
+
+	return principal, ok
+}
+
+// Principal сопоставляет проверенному при mTLS-рукопожатии сертификату клиента имя сервиса
+// и кладёт его в контекст. Идентификатор сертификата вычисляется certs.Identity.
+//
+// Если отображение principals пусто, именем сервиса служит сам идентификатор. Иначе вызовы
+// с сертификатами, которых нет в отображении, отклоняются с codes.Unauthenticated.
+//
+// Отображение services ограничивает, кто вызывает gRPC-сервисы: сервис из отображения,
+// например "access.v1.AccessV1", доступен только перечисленным для него клиентам, остальным
+// вызов отклоняется с codes.PermissionDenied. Сервисы, которых нет в отображении, доступны
+// любому клиенту с принятым сертификатом.
+type Principal struct {
+	principals map[string]string
+	services   map[string][]string
+}
+
+// NewPrincipal создаёт интерцептор, сопоставляющий сертификатам имена сервисов
+// и ограничивающий доступ к сервисам services.
+func NewPrincipal(principals map[string]string, services map[string][]string) *Principal {
+	return &Principal{principals: principals, services: services}
+}
+
+// Unary — unary-интерцептор определения сервиса-клиента.
+func (p *Principal) Unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := p.resolve(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// Stream — stream-интерцептор определения сервиса-клиента.
+func (p *Principal) Stream(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := p.resolve(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// resolve находит сертификат клиента соединения, проверяет, что сервису-клиенту разрешён
+// метод fullMethod, и возвращает контекст с именем сервиса.
+func (p *Principal) resolve(ctx context.Context, fullMethod string) (context.Context, error) {
+	identity, ok := clientIdentity(ctx)
+	if !ok {
+		return nil, apperr.New(codes.Unauthenticated, apperr.ReasonUnknownPrincipal, errorPrincipalUnknown)
+	}
+
+	principal := identity
+
+	if len(p.principals) > 0 {
+		if principal, ok = p.principals[identity]; !ok {
+			return nil, apperr.New(codes.Unauthenticated, apperr.ReasonUnknownPrincipal, errorPrincipalUnknown)
+		}
+	}
+
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if allowed, ok := p.services[service]; ok && !slices.Contains(allowed, principal) {
+		return nil, apperr.New(codes.PermissionDenied, apperr.ReasonPermissionDenied, errorPrincipalForbidden)
+	}
+
+	return context.WithValue(ctx, principalKey{}, principal), nil
+}
+
+// clientIdentity возвращает идентификатор проверенного сертификата клиента соединения.
+func clientIdentity(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	return certs.Identity(info.State.VerifiedChains[0][0]), true
+}
@@ -0,0 +1,131 @@
+package interceptor_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/based-chat/auth/internal/interceptor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	accessV1 "github.com/based-chat/auth/pkg/access/v1"
+	userV1 "github.com/based-chat/auth/pkg/user/v1"
+)
+
+// withClientCert возвращает контекст соединения, клиент которого предъявил проверенный
+// сертификат с Common Name commonName; пустой commonName означает соединение без сертификата.
+func withClientCert(commonName string) context.Context {
+	var state tls.ConnectionState
+	if commonName != "" {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestPrincipal(t *testing.T) {
+	principal := interceptor.NewPrincipal(
+		map[string]string{"chat.internal": "chat", "gateway.internal": "gateway"},
+		map[string][]string{accessV1.AccessV1_ServiceDesc.ServiceName: {"chat"}},
+	)
+
+	tests := []struct {
+		name          string
+		commonName    string
+		method        string
+		want          codes.Code
+		wantPrincipal string
+	}{
+		{
+			name:          "known principal",
+			commonName:    "gateway.internal",
+			method:        userV1.UserV1_Get_FullMethodName,
+			want:          codes.OK,
+			wantPrincipal: "gateway",
+		},
+		{
+			name:       "unknown principal",
+			commonName: "intruder.internal",
+			method:     userV1.UserV1_Get_FullMethodName,
+			want:       codes.Unauthenticated,
+		},
+		{
+			name:   "no client certificate",
+			method: userV1.UserV1_Get_FullMethodName,
+			want:   codes.Unauthenticated,
+		},
+		{
+			name:          "allowed access service",
+			commonName:    "chat.internal",
+			method:        accessV1.AccessV1_Check_FullMethodName,
+			want:          codes.OK,
+			wantPrincipal: "chat",
+		},
+		{
+			name:       "forbidden access service",
+			commonName: "gateway.internal",
+			method:     accessV1.AccessV1_Check_FullMethodName,
+			want:       codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				called bool
+				got    string
+			)
+
+			handler := func(ctx context.Context, _ any) (any, error) {
+				called = true
+				got, _ = interceptor.PrincipalFromContext(ctx)
+
+				return nil, nil
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+
+			_, err := principal.Unary(withClientCert(tt.commonName), nil, info, handler)
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("Unary() code = %v, want %v", code, tt.want)
+			}
+
+			if called != (tt.want == codes.OK) {
+				t.Errorf("handler called = %t, want %t", called, tt.want == codes.OK)
+			}
+
+			if got != tt.wantPrincipal {
+				t.Errorf("principal = %q, want %q", got, tt.wantPrincipal)
+			}
+		})
+	}
+}
+
+func TestPrincipalIdentity(t *testing.T) {
+	// Без отображения именем сервиса служит идентификатор сертификата.
+	principal := interceptor.NewPrincipal(nil, nil)
+
+	var got string
+
+	handler := func(ctx context.Context, _ any) (any, error) {
+		got, _ = interceptor.PrincipalFromContext(ctx)
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: accessV1.AccessV1_Check_FullMethodName}
+
+	if _, err := principal.Unary(withClientCert("chat.internal"), nil, info, handler); err != nil {
+		t.Fatalf("Unary() error = %v", err)
+	}
+
+	if got != "chat.internal" {
+		t.Errorf("principal = %q, want %q", got, "chat.internal")
+	}
+}
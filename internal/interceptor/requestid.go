@@ -0,0 +1,68 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/based-chat/auth/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader — заголовок метаданных с идентификатором запроса.
+const RequestIDHeader = "x-request-id"
+
+// validRequestID ограничивает принимаемые от клиента идентификаторы: они попадают в журнал как есть.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID берёт идентификатор запроса из заголовка x-request-id или, если его нет
+// или он негоден, создаёт новый. Идентификатор кладётся в контекст, откуда попадает
+// в записи журнала, и возвращается клиенту в заголовке ответа.
+func RequestID(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	return handler(withRequestID(ctx), req)
+}
+
+// RequestIDStream — stream-вариант RequestID.
+func RequestIDStream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: ss, ctx: withRequestID(ss.Context())})
+}
+
+// withRequestID возвращает контекст с идентификатором запроса и отправляет его в заголовке ответа.
+func withRequestID(ctx context.Context) context.Context {
+	id := requestIDFromMetadata(ctx)
+	if id == "" {
+		id = newRequestID()
+	}
+
+	// Ошибка возможна, только если заголовки уже отправлены, а до обработчика этого не бывает.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+
+	return logger.WithRequestID(ctx, id)
+}
+
+// requestIDFromMetadata возвращает годный идентификатор запроса из входящих метаданных.
+func requestIDFromMetadata(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get(RequestIDHeader)
+	if len(values) == 0 || !validRequestID.MatchString(values[0]) {
+		return ""
+	}
+
+	return values[0]
+}
+
+// newRequestID создаёт случайный идентификатор запроса.
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,135 @@
+package interceptor
+
+import (
+	"github.com/based-chat/auth/internal/apperr"
+	"github.com/based-chat/auth/internal/model"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionV1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionV1Alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+
+	accessV1 "github.com/based-chat/auth/pkg/access/v1"
+	authV1 "github.com/based-chat/auth/pkg/auth/v1"
+	userV1 "github.com/based-chat/auth/pkg/user/v1"
+)
+
+const (
+	errorOnlySelf        = "only the account owner or an admin may do this"
+	errorOnlyAdminRoles  = "only an admin may assign roles"
+	errorOnlyAdmin       = "only an admin may do this"
+	errorOnlyStaff       = "only staff may do this"
+	errorUnexpectedInput = "unexpected request type"
+)
+
+// Rule решает, разрешён ли вызову caller запрос req. caller равен nil для анонимного вызова.
+type Rule func(caller *model.Caller, req any) error
+
+// PublicMethods — методы, доступные без access-токена. Регистрация и вход открыты по смыслу,
+// refresh-токен сам является учётными данными, JWKS публичен, а AccessV1.Check получает
+// проверяемый токен в теле запроса. Reflection оставлен открытым для grpcurl и подобных инструментов,
+// проверки готовности — для балансировщиков и Kubernetes.
+var PublicMethods = []string{
+	reflectionV1.ServerReflection_ServerReflectionInfo_FullMethodName,
+	reflectionV1Alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+	userV1.UserV1_Create_FullMethodName,
+	authV1.AuthV1_Login_FullMethodName,
+	authV1.AuthV1_Refresh_FullMethodName,
+	authV1.AuthV1_Logout_FullMethodName,
+	authV1.AuthV1_GetJWKS_FullMethodName,
+	accessV1.AccessV1_Check_FullMethodName,
+	healthpb.Health_Check_FullMethodName,
+	healthpb.Health_List_FullMethodName,
+	healthpb.Health_Watch_FullMethodName,
+}
+
+// Rules — правила доступа к методам сервера: пользователь читает, меняет и удаляет только
+// свою учётную запись, роль, отличную от обычной, назначает только администратор,
+// и только он управляет разрешениями ролей; список и пакетное чтение пользователей доступны персоналу.
+var Rules = map[string]Rule{
+	userV1.UserV1_Create_FullMethodName: func(caller *model.Caller, req any) error {
+		r, ok := req.(*userV1.CreateRequest)
+		if !ok {
+			return apperr.New(codes.Internal, apperr.ReasonInternal, errorUnexpectedInput)
+		}
+
+		if r.GetRole() == userV1.UserRole_UNSPECIFIED || r.GetRole() == userV1.UserRole_USER {
+			return nil
+		}
+
+		if !isAdmin(caller) {
+			return apperr.New(codes.PermissionDenied, apperr.ReasonPermissionDenied, errorOnlyAdminRoles)
+		}
+
+		return nil
+	},
+	userV1.UserV1_Get_FullMethodName: func(caller *model.Caller, req any) error {
+		r, ok := req.(*userV1.GetRequest)
+		if !ok {
+			return apperr.New(codes.Internal, apperr.ReasonInternal, errorUnexpectedInput)
+		}
+
+		return selfOrAdmin(caller, r.GetId())
+	},
+	userV1.UserV1_Update_FullMethodName: func(caller *model.Caller, req any) error {
+		r, ok := req.(*userV1.UpdateRequest)
+		if !ok {
+			return apperr.New(codes.Internal, apperr.ReasonInternal, errorUnexpectedInput)
+		}
+
+		if r.GetRole() != userV1.UserRole_UNSPECIFIED && !isAdmin(caller) {
+			return apperr.New(codes.PermissionDenied, apperr.ReasonPermissionDenied, errorOnlyAdminRoles)
+		}
+
+		return selfOrAdmin(caller, r.GetId())
+	},
+	userV1.UserV1_Delete_FullMethodName: func(caller *model.Caller, req any) error {
+		r, ok := req.(*userV1.DeleteRequest)
+		if !ok {
+			return apperr.New(codes.Internal, apperr.ReasonInternal, errorUnexpectedInput)
+		}
+
+		return selfOrAdmin(caller, r.GetId())
+	},
+	userV1.UserV1_List_FullMethodName:                 staffOnly,
+	userV1.UserV1_GetMany_FullMethodName:              staffOnly,
+	accessV1.AccessV1_ListRoles_FullMethodName:        adminOnly,
+	accessV1.AccessV1_GrantPermission_FullMethodName:  adminOnly,
+	accessV1.AccessV1_RevokePermission_FullMethodName: adminOnly,
+}
+
+// adminOnly разрешает вызов только администратору.
+func adminOnly(caller *model.Caller, _ any) error {
+	if !isAdmin(caller) {
+		return apperr.New(codes.PermissionDenied, apperr.ReasonPermissionDenied, errorOnlyAdmin)
+	}
+
+	return nil
+}
+
+// staffOnly разрешает вызов администраторам, модераторам и поддержке.
+func staffOnly(caller *model.Caller, _ any) error {
+	if caller == nil {
+		return apperr.New(codes.PermissionDenied, apperr.ReasonPermissionDenied, errorOnlyStaff)
+	}
+
+	switch caller.Role {
+	case model.RoleAdmin, model.RoleModerator, model.RoleSupport:
+		return nil
+	default:
+		return apperr.New(codes.PermissionDenied, apperr.ReasonPermissionDenied, errorOnlyStaff)
+	}
+}
+
+// selfOrAdmin разрешает действие над учётной записью id её владельцу и администратору.
+func selfOrAdmin(caller *model.Caller, id int64) error {
+	if isAdmin(caller) || (caller != nil && caller.UserID == id) {
+		return nil
+	}
+
+	return apperr.New(codes.PermissionDenied, apperr.ReasonPermissionDenied, errorOnlySelf)
+}
+
+// isAdmin сообщает, является ли вызывающий администратором.
+func isAdmin(caller *model.Caller) bool {
+	return caller != nil && caller.Role == model.RoleAdmin
+}
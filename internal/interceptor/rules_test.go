@@ -0,0 +1,181 @@
+package interceptor_test
+
+import (
+	"testing"
+
+	"github.com/based-chat/auth/internal/interceptor"
+	"github.com/based-chat/auth/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	accessV1 "github.com/based-chat/auth/pkg/access/v1"
+	userV1 "github.com/based-chat/auth/pkg/user/v1"
+)
+
+func TestRules(t *testing.T) {
+	var (
+		user      = &model.Caller{UserID: 1, Role: model.RoleUser}
+		admin     = &model.Caller{UserID: 2, Role: model.RoleAdmin}
+		moderator = &model.Caller{UserID: 3, Role: model.RoleModerator}
+		support   = &model.Caller{UserID: 4, Role: model.RoleSupport}
+	)
+
+	tests := []struct {
+		name   string
+		method string
+		caller *model.Caller
+		req    any
+		want   codes.Code
+	}{
+		{
+			name:   "get self",
+			method: userV1.UserV1_Get_FullMethodName,
+			caller: user,
+			req:    &userV1.GetRequest{Id: user.UserID},
+			want:   codes.OK,
+		},
+		{
+			name:   "get other",
+			method: userV1.UserV1_Get_FullMethodName,
+			caller: user,
+			req:    &userV1.GetRequest{Id: admin.UserID},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "get other as admin",
+			method: userV1.UserV1_Get_FullMethodName,
+			caller: admin,
+			req:    &userV1.GetRequest{Id: user.UserID},
+			want:   codes.OK,
+		},
+		{
+			name:   "get anonymous",
+			method: userV1.UserV1_Get_FullMethodName,
+			req:    &userV1.GetRequest{Id: user.UserID},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "get many as user",
+			method: userV1.UserV1_GetMany_FullMethodName,
+			caller: user,
+			req:    &userV1.GetManyRequest{Ids: []int64{user.UserID}},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "get many as support",
+			method: userV1.UserV1_GetMany_FullMethodName,
+			caller: support,
+			req:    &userV1.GetManyRequest{Ids: []int64{user.UserID}},
+			want:   codes.OK,
+		},
+		{
+			name:   "list as user",
+			method: userV1.UserV1_List_FullMethodName,
+			caller: user,
+			req:    &userV1.ListRequest{},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "list as moderator",
+			method: userV1.UserV1_List_FullMethodName,
+			caller: moderator,
+			req:    &userV1.ListRequest{},
+			want:   codes.OK,
+		},
+		{
+			name:   "create user anonymously",
+			method: userV1.UserV1_Create_FullMethodName,
+			req:    &userV1.CreateRequest{Role: userV1.UserRole_USER},
+			want:   codes.OK,
+		},
+		{
+			name:   "create admin anonymously",
+			method: userV1.UserV1_Create_FullMethodName,
+			req:    &userV1.CreateRequest{Role: userV1.UserRole_ADMIN},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "create moderator as user",
+			method: userV1.UserV1_Create_FullMethodName,
+			caller: user,
+			req:    &userV1.CreateRequest{Role: userV1.UserRole_MODERATOR},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "create moderator as admin",
+			method: userV1.UserV1_Create_FullMethodName,
+			caller: admin,
+			req:    &userV1.CreateRequest{Role: userV1.UserRole_MODERATOR},
+			want:   codes.OK,
+		},
+		{
+			name:   "update self",
+			method: userV1.UserV1_Update_FullMethodName,
+			caller: user,
+			req:    &userV1.UpdateRequest{Id: user.UserID},
+			want:   codes.OK,
+		},
+		{
+			name:   "update other",
+			method: userV1.UserV1_Update_FullMethodName,
+			caller: user,
+			req:    &userV1.UpdateRequest{Id: admin.UserID},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "update own role",
+			method: userV1.UserV1_Update_FullMethodName,
+			caller: user,
+			req:    &userV1.UpdateRequest{Id: user.UserID, Role: userV1.UserRole_ADMIN},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "update role as admin",
+			method: userV1.UserV1_Update_FullMethodName,
+			caller: admin,
+			req:    &userV1.UpdateRequest{Id: user.UserID, Role: userV1.UserRole_SUPPORT},
+			want:   codes.OK,
+		},
+		{
+			name:   "delete other",
+			method: userV1.UserV1_Delete_FullMethodName,
+			caller: moderator,
+			req:    &userV1.DeleteRequest{Id: user.UserID},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "grant permission as moderator",
+			method: accessV1.AccessV1_GrantPermission_FullMethodName,
+			caller: moderator,
+			req:    &accessV1.GrantPermissionRequest{},
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "grant permission as admin",
+			method: accessV1.AccessV1_GrantPermission_FullMethodName,
+			caller: admin,
+			req:    &accessV1.GrantPermissionRequest{},
+			want:   codes.OK,
+		},
+		{
+			name:   "unexpected request type",
+			method: userV1.UserV1_Get_FullMethodName,
+			caller: admin,
+			req:    &userV1.DeleteRequest{Id: user.UserID},
+			want:   codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := interceptor.Rules[tt.method]
+			if !ok {
+				t.Fatalf("no rule for %s", tt.method)
+			}
+
+			if got := status.Code(rule(tt.caller, tt.req)); got != tt.want {
+				t.Errorf("rule(%s) code = %v, want %v", tt.method, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/based-chat/auth/internal/apperr"
+	"github.com/based-chat/auth/internal/validate"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// Validate проверяет запрос по правилам validate.v1 из proto-описания и отклоняет
+// некорректный запрос с codes.InvalidArgument, перечисляя все нарушения в google.rpc.BadRequest.
+// Так каждый метод, включая будущие, проверяется одинаково и по правилам, видимым клиентам.
+func Validate(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// ValidateStream проверяет каждое сообщение, полученное из потока.
+func ValidateStream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingStream{ServerStream: ss})
+}
+
+// validatingStream проверяет входящие сообщения потока.
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+// RecvMsg получает сообщение и проверяет его.
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return validateRequest(m)
+}
+
+// validateRequest возвращает gRPC-ошибку с нарушениями правил или nil.
+func validateRequest(req any) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	violations := validate.Message(msg)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	fieldViolations := make([]apperr.FieldViolation, len(violations))
+	for i, violation := range violations {
+		fieldViolations[i] = apperr.FieldViolation{Field: violation.Field, Description: violation.Description}
+	}
+
+	return apperr.InvalidArgument(apperr.ReasonInvalidArgument, fieldViolations...)
+}
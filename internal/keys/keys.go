@@ -0,0 +1,256 @@
+// Package keys manages the asymmetric keys used to sign and verify access tokens.
+//
+// A Set holds exactly one active key that signs new tokens and any number of retired
+// keys that are still accepted for verification. Every key is identified by its RFC 7638
+// JWK thumbprint, which is written to the kid header of issued tokens. To rotate keys
+// without invalidating live sessions, make the new key active and keep the previous one
+// among the retired keys for at least the access token TTL.
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+const (
+	// AlgorithmEdDSA — алгоритм подписи ключей Ed25519.
+	AlgorithmEdDSA = "EdDSA"
+	// AlgorithmRS256 — алгоритм подписи ключей RSA.
+	AlgorithmRS256 = "RS256"
+
+	// minRSABits — минимальный допустимый размер RSA-ключа.
+	minRSABits = 2048
+)
+
+var (
+	// ErrKeyNotFound возвращается, если ключа с указанным kid нет в наборе.
+	ErrKeyNotFound = errors.New("signing key not found")
+
+	errUnsupportedKey = errors.New("unsupported key type")
+	errWeakRSAKey     = errors.New("rsa key is too short")
+	errNotSigningKey  = errors.New("active key must contain a private key")
+)
+
+// Key — ключ подписи с идентификатором kid.
+// Private равен nil у ключей, загруженных только для проверки подписи.
+type Key struct {
+	ID        string
+	Algorithm string
+	Private   crypto.Signer
+	Public    crypto.PublicKey
+}
+
+// NewKey оборачивает закрытый или открытый ключ Ed25519/RSA, определяя алгоритм и kid.
+func NewKey(key any) (*Key, error) {
+	var (
+		private crypto.Signer
+		public  crypto.PublicKey
+	)
+
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		private, public = k, k.Public()
+	case *rsa.PrivateKey:
+		private, public = k, k.Public()
+	case ed25519.PublicKey, *rsa.PublicKey:
+		public = k
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedKey, key)
+	}
+
+	jwk, err := toJWK(public)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{
+		ID:        jwk.KeyID,
+		Algorithm: jwk.Algorithm,
+		Private:   private,
+		Public:    public,
+	}, nil
+}
+
+// Set — набор ключей: активный для подписи и выведенные из оборота для проверки.
+// Набор безопасен для конкурентного использования и может обновляться на лету через Update.
+type Set struct {
+	mu     sync.RWMutex
+	active *Key
+	byID   map[string]*Key
+}
+
+// NewSet создаёт набор ключей. Активный ключ обязан содержать закрытую часть.
+func NewSet(active *Key, retired ...*Key) (*Set, error) {
+	if active.Private == nil {
+		return nil, errNotSigningKey
+	}
+
+	byID := make(map[string]*Key, len(retired)+1)
+	for _, key := range retired {
+		byID[key.ID] = key
+	}
+
+	byID[active.ID] = active
+
+	return &Set{
+		active: active,
+		byID:   byID,
+	}, nil
+}
+
+// Active возвращает ключ, которым подписываются новые токены.
+func (s *Set) Active() *Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.active
+}
+
+// Update заменяет ключи набора ключами next. Прежний активный ключ остаётся в наборе
+// для проверки подписи, чтобы замена файла ключа не обрывала уже выданные сессии.
+func (s *Set) Update(next *Set) {
+	next.mu.RLock()
+	active := next.active
+	byID := make(map[string]*Key, len(next.byID)+1)
+
+	for id, key := range next.byID {
+		byID[id] = key
+	}
+	next.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := byID[s.active.ID]; !ok {
+		byID[s.active.ID] = &Key{ID: s.active.ID, Algorithm: s.active.Algorithm, Public: s.active.Public}
+	}
+
+	s.active = active
+	s.byID = byID
+}
+
+// Lookup возвращает ключ по kid или ErrKeyNotFound.
+func (s *Set) Lookup(kid string) (*Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.byID[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, kid)
+	}
+
+	return key, nil
+}
+
+// JWK — открытый ключ в формате RFC 7517.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKS — набор открытых ключей в формате RFC 7517.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS возвращает открытые части всех ключей набора; активный ключ идёт первым.
+func (s *Set) JWKS() JWKS {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := JWKS{Keys: make([]JWK, 0, len(s.byID))}
+
+	active, _ := toJWK(s.active.Public)
+	set.Keys = append(set.Keys, active)
+
+	retired := make([]string, 0, len(s.byID))
+	for id := range s.byID {
+		if id != s.active.ID {
+			retired = append(retired, id)
+		}
+	}
+
+	sort.Strings(retired)
+
+	for _, id := range retired {
+		jwk, _ := toJWK(s.byID[id].Public)
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+// toJWK строит JWK открытого ключа и вычисляет его kid как отпечаток RFC 7638.
+func toJWK(public crypto.PublicKey) (JWK, error) {
+	var jwk JWK
+
+	switch k := public.(type) {
+	case ed25519.PublicKey:
+		jwk = JWK{
+			KeyType:   "OKP",
+			Algorithm: AlgorithmEdDSA,
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(k),
+		}
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSABits {
+			return JWK{}, fmt.Errorf("%w: %d bits, need at least %d", errWeakRSAKey, k.N.BitLen(), minRSABits)
+		}
+
+		jwk = JWK{
+			KeyType:   "RSA",
+			Algorithm: AlgorithmRS256,
+			N:         base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+	default:
+		return JWK{}, fmt.Errorf("%w: %T", errUnsupportedKey, public)
+	}
+
+	jwk.Use = "sig"
+	jwk.KeyID = thumbprint(jwk)
+
+	return jwk, nil
+}
+
+// thumbprint вычисляет отпечаток JWK по RFC 7638: SHA-256 от обязательных полей
+// в лексикографическом порядке без пробелов.
+func thumbprint(jwk JWK) string {
+	var members any
+
+	switch jwk.KeyType {
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	default:
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N}
+	}
+
+	// Маршалинг структуры из строковых полей не может завершиться ошибкой.
+	raw, _ := json.Marshal(members)
+	sum := sha256.Sum256(raw)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
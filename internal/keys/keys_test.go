@@ -0,0 +1,224 @@
+package keys_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/based-chat/auth/internal/keys"
+)
+
+// thumbprint вычисляет kid ключа jwk по RFC 7638 независимо от пакета keys.
+func thumbprint(jwk keys.JWK) string {
+	var members string
+
+	switch jwk.KeyType {
+	case "OKP":
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, jwk.Curve, jwk.KeyType, jwk.X)
+	default:
+		members = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, jwk.E, jwk.KeyType, jwk.N)
+	}
+
+	sum := sha256.Sum256([]byte(members))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func newRSAKey(t *testing.T, bits int) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+
+	return key
+}
+
+func newKey(t *testing.T, raw any) *keys.Key {
+	t.Helper()
+
+	key, err := keys.NewKey(raw)
+	if err != nil {
+		t.Fatalf("NewKey() error = %v", err)
+	}
+
+	return key
+}
+
+func TestNewKeyRFC8037Thumbprint(t *testing.T) {
+	// Ключ и отпечаток из RFC 8037, приложение A.3.
+	x, err := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	if err != nil {
+		t.Fatalf("DecodeString() error = %v", err)
+	}
+
+	key := newKey(t, ed25519.PublicKey(x))
+
+	if want := "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"; key.ID != want {
+		t.Errorf("kid = %q, want %q", key.ID, want)
+	}
+
+	if key.Algorithm != keys.AlgorithmEdDSA {
+		t.Errorf("algorithm = %q, want %q", key.Algorithm, keys.AlgorithmEdDSA)
+	}
+}
+
+func TestNewKey(t *testing.T) {
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+
+	rsaPrivate := newRSAKey(t, 2048)
+
+	ecPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		raw           any
+		wantAlgorithm string
+		wantPrivate   bool
+		wantErr       bool
+	}{
+		{name: "ed25519 private", raw: edPrivate, wantAlgorithm: keys.AlgorithmEdDSA, wantPrivate: true},
+		{name: "ed25519 public", raw: edPrivate.Public(), wantAlgorithm: keys.AlgorithmEdDSA},
+		{name: "rsa private", raw: rsaPrivate, wantAlgorithm: keys.AlgorithmRS256, wantPrivate: true},
+		{name: "rsa public", raw: &rsaPrivate.PublicKey, wantAlgorithm: keys.AlgorithmRS256},
+		{name: "weak rsa", raw: newRSAKey(t, 1024), wantErr: true},
+		{name: "ecdsa", raw: ecPrivate, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := keys.NewKey(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if key.Algorithm != tt.wantAlgorithm {
+				t.Errorf("algorithm = %q, want %q", key.Algorithm, tt.wantAlgorithm)
+			}
+
+			if (key.Private != nil) != tt.wantPrivate {
+				t.Errorf("private part present = %v, want %v", key.Private != nil, tt.wantPrivate)
+			}
+
+			set, err := keys.NewSet(newKey(t, edPrivate), key)
+			if err != nil {
+				t.Fatalf("NewSet() error = %v", err)
+			}
+
+			// kid закрытого и открытого ключа совпадает и равен отпечатку его JWK.
+			for _, jwk := range set.JWKS().Keys {
+				if jwk.KeyID == key.ID && thumbprint(jwk) != key.ID {
+					t.Errorf("kid = %q, want RFC 7638 thumbprint %q", key.ID, thumbprint(jwk))
+				}
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	active, err := keys.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	retiredEd, err := keys.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	retiredRSA := newKey(t, &newRSAKey(t, 2048).PublicKey)
+
+	set, err := keys.NewSet(active, newKey(t, retiredEd.Public), retiredRSA)
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+
+	if set.Active().ID != active.ID {
+		t.Errorf("Active() kid = %q, want %q", set.Active().ID, active.ID)
+	}
+
+	jwks := set.JWKS()
+	if len(jwks.Keys) != 3 {
+		t.Fatalf("JWKS() has %d keys, want 3", len(jwks.Keys))
+	}
+
+	if jwks.Keys[0].KeyID != active.ID {
+		t.Errorf("first JWKS kid = %q, want the active key %q", jwks.Keys[0].KeyID, active.ID)
+	}
+
+	for _, jwk := range jwks.Keys {
+		if jwk.KeyID != thumbprint(jwk) {
+			t.Errorf("JWKS kid = %q, want RFC 7638 thumbprint %q", jwk.KeyID, thumbprint(jwk))
+		}
+
+		if jwk.Use != "sig" {
+			t.Errorf("JWKS use = %q, want sig", jwk.Use)
+		}
+
+		key, err := set.Lookup(jwk.KeyID)
+		if err != nil {
+			t.Errorf("Lookup(%q) error = %v", jwk.KeyID, err)
+			continue
+		}
+
+		if key.Algorithm != jwk.Algorithm {
+			t.Errorf("JWKS alg = %q, want %q", jwk.Algorithm, key.Algorithm)
+		}
+	}
+
+	if _, err := set.Lookup("unknown"); !errors.Is(err, keys.ErrKeyNotFound) {
+		t.Errorf("Lookup(unknown) error = %v, want %v", err, keys.ErrKeyNotFound)
+	}
+}
+
+func TestNewSetRequiresPrivateKey(t *testing.T) {
+	key, err := keys.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if _, err := keys.NewSet(newKey(t, key.Public)); err == nil {
+		t.Error("NewSet() with a public active key error = nil, want an error")
+	}
+}
+
+func TestLoadOrGenerate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "signing.pem")
+
+	generated, err := keys.LoadOrGenerate(path)
+	if err != nil {
+		t.Fatalf("LoadOrGenerate() error = %v", err)
+	}
+
+	loaded, err := keys.LoadOrGenerate(path)
+	if err != nil {
+		t.Fatalf("LoadOrGenerate() of the saved key error = %v", err)
+	}
+
+	if loaded.ID != generated.ID || loaded.Private == nil {
+		t.Errorf("LoadOrGenerate() loaded kid %q, want the saved signing key %q", loaded.ID, generated.ID)
+	}
+
+	if _, err := keys.LoadFile(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("LoadFile() of a missing file error = nil, want an error")
+	}
+}
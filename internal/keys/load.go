@@ -0,0 +1,144 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	pemTypePrivateKey    = "PRIVATE KEY"
+	pemTypeRSAPrivateKey = "RSA PRIVATE KEY"
+	pemTypePublicKey     = "PUBLIC KEY"
+
+	generatedKeyFileMode = 0o600
+	generatedKeyDirMode  = 0o700
+)
+
+var (
+	errFailedReadKey     = errors.New("failed to read key file")
+	errFailedParseKey    = errors.New("failed to parse key file")
+	errFailedGenerateKey = errors.New("failed to generate key")
+	errFailedWriteKey    = errors.New("failed to write key file")
+	errNoPEMBlock        = errors.New("no PEM block found")
+	errUnknownPEMBlock   = errors.New("unsupported PEM block type")
+)
+
+// LoadSet собирает набор ключей: активный берётся из activePath через LoadOrGenerate,
+// выведенные из оборота — из retiredPaths через LoadFile.
+func LoadSet(activePath string, retiredPaths []string) (*Set, error) {
+	active, err := LoadOrGenerate(activePath)
+	if err != nil {
+		return nil, err
+	}
+
+	retired := make([]*Key, 0, len(retiredPaths))
+
+	for _, path := range retiredPaths {
+		key, err := LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		retired = append(retired, key)
+	}
+
+	return NewSet(active, retired...)
+}
+
+// LoadOrGenerate загружает закрытый ключ из path. Если файла нет, генерирует ключ Ed25519
+// и сохраняет его туда, чтобы следующий запуск подписывал тем же ключом. При пустом path
+// ключ генерируется только в памяти и выданные токены не переживут перезапуск.
+func LoadOrGenerate(path string) (*Key, error) {
+	if path == "" {
+		return Generate()
+	}
+
+	key, err := LoadFile(path)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return key, err
+	}
+
+	key, err = Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key.Private)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedWriteKey, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), generatedKeyDirMode); err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedWriteKey, err)
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: der})
+	if err := os.WriteFile(path, data, generatedKeyFileMode); err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedWriteKey, err)
+	}
+
+	return key, nil
+}
+
+// Generate создаёт новый ключ Ed25519.
+func Generate() (*Key, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedGenerateKey, err)
+	}
+
+	return NewKey(private)
+}
+
+// LoadFile читает ключ из PEM-файла. Поддерживаются закрытые ключи PKCS#8 и PKCS#1
+// и открытые ключи PKIX; открытого ключа достаточно для выведенного из оборота ключа.
+func LoadFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedReadKey, err)
+	}
+
+	key, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return key, nil
+}
+
+// Parse разбирает ключ из PEM-блока.
+func Parse(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %w", errFailedParseKey, errNoPEMBlock)
+	}
+
+	var (
+		raw any
+		err error
+	)
+
+	switch block.Type {
+	case pemTypePrivateKey:
+		raw, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case pemTypeRSAPrivateKey:
+		raw, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case pemTypePublicKey:
+		raw, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: %w: %s", errFailedParseKey, errUnknownPEMBlock, block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedParseKey, err)
+	}
+
+	return NewKey(raw)
+}
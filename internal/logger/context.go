@@ -0,0 +1,19 @@
+package logger
+
+import (
+	"context"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID возвращает копию ctx с идентификатором запроса id; он попадёт во все записи
+// журнала, сделанные с этим контекстом.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext возвращает идентификатор запроса из ctx, если он есть.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
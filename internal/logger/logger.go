@@ -0,0 +1,71 @@
+// Package logger configures structured logging with log/slog.
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/based-chat/auth/internal/config"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// New создаёт журнал по конфигурации, пишущий в stderr, и делает его журналом по умолчанию:
+// через него идут и вызовы slog.InfoContext и т. п., и записи стандартного пакета log.
+func New(cfg config.LoggingConfig) *slog.Logger {
+	logger := slog.New(newContextHandler(newHandler(os.Stderr, cfg)))
+	slog.SetDefault(logger)
+
+	return logger
+}
+
+// Fatal пишет в журнал по умолчанию ошибку err с сообщением msg и завершает процесс с кодом 1.
+func Fatal(msg string, err error) {
+	slog.Error(msg, slog.Any("error", err))
+	os.Exit(1)
+}
+
+// newHandler создаёт обработчик записей в формате cfg.Format().
+func newHandler(w io.Writer, cfg config.LoggingConfig) slog.Handler {
+	options := &slog.HandlerOptions{Level: cfg.Level()}
+
+	if cfg.Format() == config.LogFormatText {
+		return slog.NewTextHandler(w, options)
+	}
+
+	return slog.NewJSONHandler(w, options)
+}
+
+// contextHandler дополняет записи идентификаторами запроса и трассировки из контекста.
+type contextHandler struct {
+	slog.Handler
+}
+
+// newContextHandler оборачивает next.
+func newContextHandler(next slog.Handler) *contextHandler {
+	return &contextHandler{Handler: next}
+}
+
+// Handle добавляет к записи request_id и trace_id, если они есть в ctx.
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs сохраняет обёртку у журнала с атрибутами.
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return newContextHandler(h.Handler.WithAttrs(attrs))
+}
+
+// WithGroup сохраняет обёртку у журнала с группой.
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return newContextHandler(h.Handler.WithGroup(name))
+}
@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// redacted заменяет значения чувствительных полей.
+const redacted = "REDACTED"
+
+// sensitiveFields — части имён полей, значения которых не попадают в журнал: пароли,
+// адреса почты и прочие секреты.
+var sensitiveFields = []string{
+	"password",
+	"email",
+	"secret",
+}
+
+// sensitiveTokens — окончания имён полей с токенами, включая токены страниц: в них зашифрован
+// курсор списка. Сроки действия токенов остаются в журнале.
+var sensitiveTokens = []string{
+	"access_token",
+	"refresh_token",
+	"page_token",
+}
+
+// Proto возвращает значение для журнала, которое выводит msg в виде JSON со скрытыми
+// чувствительными полями. Сообщение сериализуется, только если запись действительно пишется.
+func Proto(msg proto.Message) slog.LogValuer {
+	return protoValue{msg: msg}
+}
+
+// protoValue откладывает сериализацию сообщения до записи в журнал.
+type protoValue struct {
+	msg proto.Message
+}
+
+// LogValue сериализует сообщение и скрывает чувствительные поля.
+func (v protoValue) LogValue() slog.Value {
+	if v.msg == nil {
+		return slog.AnyValue(nil)
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(v.msg)
+	if err != nil {
+		return slog.StringValue("<" + err.Error() + ">")
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return slog.StringValue("<" + err.Error() + ">")
+	}
+
+	return slog.AnyValue(redact(fields))
+}
+
+// redact скрывает значения чувствительных полей на всех уровнях вложенности.
+func redact(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if isSensitive(key) {
+				value[key] = redacted
+			} else {
+				value[key] = redact(field)
+			}
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = redact(item)
+		}
+	}
+
+	return value
+}
+
+// isSensitive сообщает, скрывается ли значение поля name.
+func isSensitive(name string) bool {
+	for _, part := range sensitiveFields {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+
+	for _, suffix := range sensitiveTokens {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,135 @@
+package logger_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/based-chat/auth/internal/logger"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	accessV1 "github.com/based-chat/auth/pkg/access/v1"
+	authV1 "github.com/based-chat/auth/pkg/auth/v1"
+	userV1 "github.com/based-chat/auth/pkg/user/v1"
+)
+
+const redacted = "REDACTED"
+
+// field возвращает значение по пути вида "users.0.email" в сообщении, подготовленном для журнала.
+func field(t *testing.T, value any, path string) any {
+	t.Helper()
+
+	for _, part := range strings.Split(path, ".") {
+		switch node := value.(type) {
+		case map[string]any:
+			value = node[part]
+		case []any:
+			i, err := strconv.Atoi(part)
+			if err != nil || i >= len(node) {
+				t.Fatalf("path %s: no element %s", path, part)
+			}
+
+			value = node[i]
+		default:
+			t.Fatalf("path %s: %s is not a message or list", path, part)
+		}
+	}
+
+	return value
+}
+
+func TestProtoRedact(t *testing.T) {
+	expires := timestamppb.New(time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		msg  proto.Message
+		// hidden — поля, значения которых скрыты.
+		hidden []string
+		// kept — поля, значения которых остаются в журнале.
+		kept map[string]any
+	}{
+		{
+			name:   "password and email",
+			msg:    &userV1.CreateRequest{Name: "alice", Email: "alice@example.com", Password: "secret"},
+			hidden: []string{"email", "password"},
+			kept:   map[string]any{"name": "alice"},
+		},
+		{
+			name:   "email wrapper",
+			msg:    &userV1.UpdateRequest{Id: 1, Email: wrapperspb.String("alice@example.com")},
+			hidden: []string{"email"},
+			kept:   map[string]any{"id": "1"},
+		},
+		{
+			name: "nested message",
+			msg: &authV1.LoginResponse{UserId: 1, Tokens: &authV1.Tokens{
+				TokenType:            "Bearer",
+				AccessToken:          "access",
+				AccessTokenExpiresAt: expires,
+				RefreshToken:         "refresh",
+			}},
+			hidden: []string{"tokens.access_token", "tokens.refresh_token"},
+			kept: map[string]any{
+				"user_id":                        "1",
+				"tokens.token_type":              "Bearer",
+				"tokens.access_token_expires_at": "2025-10-01T12:00:00Z",
+			},
+		},
+		{
+			name:   "token suffix",
+			msg:    &accessV1.CheckRequest{AccessToken: "access", Endpoint: "/chat.v1.ChatV1/Send"},
+			hidden: []string{"access_token"},
+			kept:   map[string]any{"endpoint": "/chat.v1.ChatV1/Send"},
+		},
+		{
+			name: "page token and nested filter",
+			msg: &userV1.ListRequest{
+				PageSize:  10,
+				PageToken: "cursor",
+				Filter:    &userV1.ListFilter{EmailPrefix: "alice", NamePrefix: "al"},
+			},
+			hidden: []string{"page_token", "filter.email_prefix"},
+			kept:   map[string]any{"page_size": float64(10), "filter.name_prefix": "al"},
+		},
+		{
+			name: "list of messages",
+			msg: &userV1.ListResponse{
+				Users: []*userV1.GetResponse{
+					{Id: 1, Name: "alice", Email: "alice@example.com"},
+					{Id: 2, Name: "bob", Email: "bob@example.com"},
+				},
+				NextPageToken: "cursor",
+			},
+			hidden: []string{"users.0.email", "users.1.email", "next_page_token"},
+			kept:   map[string]any{"users.0.name": "alice", "users.1.name": "bob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := logger.Proto(tt.msg).LogValue().Any()
+
+			for _, path := range tt.hidden {
+				if got := field(t, value, path); got != redacted {
+					t.Errorf("%s = %v, want %s", path, got, redacted)
+				}
+			}
+
+			for path, want := range tt.kept {
+				if got := field(t, value, path); got != want {
+					t.Errorf("%s = %v, want %v", path, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestProtoNil(t *testing.T) {
+	if got := logger.Proto(nil).LogValue().Any(); got != nil {
+		t.Errorf("Proto(nil) = %v, want nil", got)
+	}
+}
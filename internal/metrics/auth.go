@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Результаты входа и обмена refresh-токена, которыми размечаются счётчики Auth.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+	ResultError   = "error"
+)
+
+// Причины блокировок, которыми размечается счётчик lockouts_total.
+const (
+	// LockoutRefreshTokenReuse — все сессии семейства отозваны после повторного предъявления
+	// refresh-токена.
+	LockoutRefreshTokenReuse = "refresh_token_reuse"
+	// LockoutFailedLogins — вход по email заблокирован после серии неудачных попыток.
+	LockoutFailedLogins = "failed_logins"
+)
+
+// Auth считает исходы входа, обмена refresh-токенов и блокировки сессий и учётных записей.
+type Auth struct {
+	logins    *prometheus.CounterVec
+	refreshes *prometheus.CounterVec
+	lockouts  *prometheus.CounterVec
+}
+
+// NewAuth создаёт счётчики аутентификации и регистрирует их в registry.
+func NewAuth(registry prometheus.Registerer) *Auth {
+	m := &Auth{
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Number of login attempts, by result: success, failure (bad credentials) or error.",
+		}, []string{"result"}),
+		refreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "token_refreshes_total",
+			Help:      "Number of refresh token exchanges, by result: success, failure (rejected token) or error.",
+		}, []string{"result"}),
+		lockouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "lockouts_total",
+			Help:      "Number of times sessions or accounts were locked out, by reason.",
+		}, []string{"reason"}),
+	}
+
+	for _, result := range []string{ResultSuccess, ResultFailure, ResultError} {
+		m.logins.WithLabelValues(result)
+		m.refreshes.WithLabelValues(result)
+	}
+
+	m.lockouts.WithLabelValues(LockoutRefreshTokenReuse)
+	m.lockouts.WithLabelValues(LockoutFailedLogins)
+
+	registry.MustRegister(m.logins, m.refreshes, m.lockouts)
+
+	return m
+}
+
+// Login учитывает попытку входа с результатом result.
+func (m *Auth) Login(result string) {
+	m.logins.WithLabelValues(result).Inc()
+}
+
+// Refresh учитывает обмен refresh-токена с результатом result.
+func (m *Auth) Refresh(result string) {
+	m.refreshes.WithLabelValues(result).Inc()
+}
+
+// Lockout учитывает блокировку по причине reason.
+func (m *Auth) Lockout(reason string) {
+	m.lockouts.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GRPC считает запросы к gRPC-серверу и время их обработки по методу и коду ответа.
+type GRPC struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewGRPC создаёт метрики gRPC-сервера и регистрирует их в registry.
+func NewGRPC(registry prometheus.Registerer) *GRPC {
+	m := &GRPC{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Number of gRPC requests handled, by method and status code.",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Time spent handling gRPC requests, by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+	}
+
+	registry.MustRegister(m.requests, m.duration)
+
+	return m
+}
+
+// Unary учитывает unary-вызов. Интерцептор ставится перед проверкой сертификата клиента
+// и аутентификацией, чтобы учитывались и отклонённые ими запросы.
+func (m *GRPC) Unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.observe(info.FullMethod, err, start)
+
+	return resp, err
+}
+
+// Stream учитывает потоковый вызов целиком, от открытия до закрытия потока.
+func (m *GRPC) Stream(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	m.observe(info.FullMethod, err, start)
+
+	return err
+}
+
+// observe записывает результат вызова method, начатого в start.
+func (m *GRPC) observe(method string, err error, start time.Time) {
+	code := status.Code(err).String()
+
+	m.requests.WithLabelValues(method, code).Inc()
+	m.duration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,32 @@
+// Package metrics collects Prometheus metrics for RPCs, the database pool and auth outcomes.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path — путь, по которому отдаются метрики.
+const Path = "/metrics"
+
+// namespace — общий префикс имён метрик сервиса.
+const namespace = "auth"
+
+// NewRegistry создаёт реестр метрик со стандартными метриками рантайма Go и процесса.
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return registry
+}
+
+// Handler отдаёт метрики реестра в формате Prometheus.
+func Handler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
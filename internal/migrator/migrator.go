@@ -0,0 +1,157 @@
+// Package migrator применяет встроенные миграции схемы через goose.
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// lockID — ключ advisory-блокировки PostgreSQL, под которой выполняются миграции.
+// Реплики, стартующие одновременно, ждут друг друга, а не применяют миграции параллельно.
+const lockID int64 = 0x61757468
+
+const (
+	CommandUp     = "up"
+	CommandDown   = "down"
+	CommandStatus = "status"
+	CommandRedo   = "redo"
+)
+
+var (
+	errFailedOpen     = errors.New("failed to open database")
+	errFailedLock     = errors.New("failed to create migration lock")
+	errFailedProvider = errors.New("failed to create migration provider")
+	errFailedUp       = errors.New("failed to apply migrations")
+	errFailedDown     = errors.New("failed to roll back migration")
+	errFailedStatus   = errors.New("failed to get migration status")
+	errUnknownCommand = errors.New("unknown migrate command")
+)
+
+// Migrator применяет миграции из fs.FS к базе PostgreSQL.
+type Migrator struct {
+	db       *sql.DB
+	provider *goose.Provider
+}
+
+// New открывает соединение с базой по dsn и готовит миграции из migrations.
+// Все команды выполняются под advisory-блокировкой сессии.
+func New(dsn string, migrations fs.FS) (*Migrator, error) {
+	config, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedOpen, err)
+	}
+
+	db := stdlib.OpenDB(*config)
+
+	locker, err := lock.NewPostgresSessionLocker(lock.WithLockID(lockID))
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("%w: %w", errFailedLock, err)
+	}
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations,
+		goose.WithSessionLocker(locker),
+		goose.WithVerbose(true),
+	)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("%w: %w", errFailedProvider, err)
+	}
+
+	return &Migrator{db: db, provider: provider}, nil
+}
+
+// Run выполняет команду command (up, down, status, redo); статус печатается в out.
+func (m *Migrator) Run(ctx context.Context, command string, out io.Writer) error {
+	switch command {
+	case CommandUp:
+		return m.Up(ctx)
+	case CommandDown:
+		return m.Down(ctx)
+	case CommandStatus:
+		return m.Status(ctx, out)
+	case CommandRedo:
+		return m.Redo(ctx)
+	default:
+		return fmt.Errorf("%w: %q", errUnknownCommand, command)
+	}
+}
+
+// Up применяет все ещё не применённые миграции.
+func (m *Migrator) Up(ctx context.Context) error {
+	if _, err := m.provider.Up(ctx); err != nil {
+		return fmt.Errorf("%w: %w", errFailedUp, err)
+	}
+
+	return nil
+}
+
+// Down откатывает последнюю применённую миграцию.
+func (m *Migrator) Down(ctx context.Context) error {
+	if _, err := m.provider.Down(ctx); err != nil {
+		return fmt.Errorf("%w: %w", errFailedDown, err)
+	}
+
+	return nil
+}
+
+// Redo откатывает последнюю применённую миграцию и применяет её заново.
+func (m *Migrator) Redo(ctx context.Context) error {
+	if err := m.Down(ctx); err != nil {
+		return err
+	}
+
+	if _, err := m.provider.UpByOne(ctx); err != nil {
+		return fmt.Errorf("%w: %w", errFailedUp, err)
+	}
+
+	return nil
+}
+
+// Status печатает в out список миграций с временем применения.
+func (m *Migrator) Status(ctx context.Context, out io.Writer) error {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errFailedStatus, err)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "APPLIED AT\tMIGRATION")
+
+	for _, status := range statuses {
+		appliedAt := "Pending"
+		if status.State == goose.StateApplied {
+			appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", appliedAt, status.Source.Path)
+	}
+
+	return w.Flush()
+}
+
+// HasPending сообщает, остались ли неприменённые миграции.
+func (m *Migrator) HasPending(ctx context.Context) (bool, error) {
+	pending, err := m.provider.HasPending(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", errFailedStatus, err)
+	}
+
+	return pending, nil
+}
+
+// Close закрывает соединение с базой.
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
@@ -0,0 +1,20 @@
+package model
+
+// AccessDecision — результат проверки доступа пользователя к эндпоинту.
+type AccessDecision struct {
+	Allowed bool
+	UserID  int64
+	Role    Role
+}
+
+// Caller — аутентифицированный по access-токену вызывающий пользователь.
+type Caller struct {
+	UserID int64
+	Role   Role
+}
+
+// RoleInfo — роль вместе с выданными ей разрешениями.
+type RoleInfo struct {
+	Role        Role
+	Permissions []string
+}
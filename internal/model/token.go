@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// RefreshToken — выданный пользователю refresh-токен.
+// Сам токен не хранится: по нему вычисляется и сохраняется только хеш.
+//
+// Все токены, полученные ротацией из одного входа, образуют семейство с общим FamilyID.
+// RotatedAt выставляется, когда токен обменян на следующий; повторное предъявление такого
+// токена означает его утечку и отзывает всё семейство.
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+}
+
+// Tokens — пара токенов, выдаваемая при входе и обновлении сессии.
+type Tokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
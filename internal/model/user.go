@@ -0,0 +1,73 @@
+// Package model provides domain entities of the auth service.
+package model
+
+import "time"
+
+// Role — роль пользователя в доменной модели.
+// Значение совпадает с именем роли в таблице user_role.
+type Role string
+
+const (
+	RoleUnspecified Role = "unspecified"
+	RoleUser        Role = "user"
+	RoleAdmin       Role = "admin"
+	RoleModerator   Role = "moderator"
+	RoleSupport     Role = "support"
+)
+
+// User — пользователь в том виде, в котором он хранится в базе данных.
+type User struct {
+	ID    int64
+	Name  string
+	Email string
+	// PasswordHash — хеш пароля в формате PHC (argon2id) или bcrypt для импортированных учётных записей.
+	PasswordHash string
+	Role         Role
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// UserUpdate — набор изменяемых полей пользователя.
+// Nil-поле означает, что значение остаётся прежним.
+type UserUpdate struct {
+	Name  *string
+	Email *string
+	Role  *Role
+}
+
+// UserSortField — поле, по которому сортируется список пользователей.
+type UserSortField string
+
+const (
+	UserSortByID        UserSortField = "id"
+	UserSortByCreatedAt UserSortField = "created_at"
+	UserSortByName      UserSortField = "name"
+	UserSortByEmail     UserSortField = "email"
+)
+
+// UserListFilter — условия отбора пользователей. Пустые поля не ограничивают выборку.
+type UserListFilter struct {
+	Roles         []Role
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	EmailPrefix   string
+	NamePrefix    string
+}
+
+// UserCursor — ключ последнего пользователя предыдущей страницы.
+// Из полей сортировки используется только то, по которому упорядочен список, и ID.
+type UserCursor struct {
+	ID        int64
+	CreatedAt time.Time
+	Name      string
+	Email     string
+}
+
+// UserListQuery — запрос страницы списка пользователей.
+type UserListQuery struct {
+	Filter     UserListFilter
+	SortField  UserSortField
+	Descending bool
+	Limit      int
+	After      *UserCursor
+}
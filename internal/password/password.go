@@ -0,0 +1,174 @@
+// Package password provides hashing and verification of user passwords.
+//
+// New hashes are produced with argon2id and encoded in PHC string format:
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+//
+// Hashes created by bcrypt ($2a$, $2b$, $2y$) are accepted for verification so that
+// accounts imported from legacy systems can sign in; such hashes are always reported
+// as requiring a rehash.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	argon2idPrefix = "$argon2id$"
+	// maxSegmentLength ограничивает длину соли и ключа в разбираемом хеше.
+	maxSegmentLength = 1024
+)
+
+var (
+	errFailedGenerateSalt = errors.New("failed to generate salt")
+	errInvalidHash        = errors.New("invalid password hash")
+	errUnsupportedHash    = errors.New("unsupported password hash algorithm")
+	errIncompatibleHash   = errors.New("incompatible argon2 version")
+)
+
+var bcryptPrefixes = []string{"$2a$", "$2b$", "$2y$"}
+
+// Params — параметры argon2id, с которыми создаются новые хеши.
+type Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// Hasher хеширует пароли и проверяет их по сохранённым хешам.
+type Hasher struct {
+	params Params
+}
+
+// NewHasher создаёт Hasher с заданными параметрами argon2id.
+func NewHasher(params Params) *Hasher {
+	return &Hasher{params: params}
+}
+
+// Hash возвращает argon2id-хеш пароля в формате PHC со случайной солью.
+func (h *Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("%w: %w", errFailedGenerateSalt, err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism,
+		h.params.KeyLength)
+
+	return encode(h.params, salt, key), nil
+}
+
+// Verify проверяет пароль по сохранённому хешу.
+// ok сообщает, совпал ли пароль; needsRehash — что хеш создан устаревшим алгоритмом или
+// с параметрами, отличными от текущих, и после успешного входа его стоит пересчитать через Hash.
+func (h *Hasher) Verify(password, encoded string) (ok, needsRehash bool, err error) {
+	if isBcrypt(encoded) {
+		err = bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, false, nil
+		}
+
+		if err != nil {
+			return false, false, fmt.Errorf("%w: %w", errInvalidHash, err)
+		}
+
+		return true, true, nil
+	}
+
+	params, salt, key, err := decode(encoded)
+	if err != nil {
+		return false, false, err
+	}
+
+	// Пересчитываем ключ с параметрами из хеша, а не с текущими: иначе после смены
+	// настроек ни один из ранее созданных хешей не прошёл бы проверку.
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism,
+		params.KeyLength)
+
+	if subtle.ConstantTimeCompare(key, candidate) != 1 {
+		return false, false, nil
+	}
+
+	return true, params != h.params, nil
+}
+
+// isBcrypt сообщает, создан ли хеш алгоритмом bcrypt.
+func isBcrypt(encoded string) bool {
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(encoded, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// encode собирает PHC-строку argon2id.
+func encode(params Params, salt, key []byte) string {
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		params.Memory,
+		params.Iterations,
+		params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+// decode разбирает PHC-строку argon2id и возвращает параметры, соль и ключ.
+func decode(encoded string) (Params, []byte, []byte, error) {
+	if !strings.HasPrefix(encoded, argon2idPrefix) {
+		return Params{}, nil, nil, errUnsupportedHash
+	}
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return Params{}, nil, nil, errInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: %w", errInvalidHash, err)
+	}
+
+	if version != argon2.Version {
+		return Params{}, nil, nil, errIncompatibleHash
+	}
+
+	var params Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations,
+		&params.Parallelism); err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: %w", errInvalidHash, err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: %w", errInvalidHash, err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: %w", errInvalidHash, err)
+	}
+
+	if len(salt) == 0 || len(key) == 0 || len(salt) > maxSegmentLength || len(key) > maxSegmentLength {
+		return Params{}, nil, nil, errInvalidHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
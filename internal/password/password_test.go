@@ -0,0 +1,133 @@
+package password_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/based-chat/auth/internal/password"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testParams — дешёвые параметры argon2id, чтобы тесты шли быстро.
+var testParams = password.Params{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHasherHash(t *testing.T) {
+	hasher := password.NewHasher(testParams)
+
+	first, err := hasher.Hash("Passw0rd")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	if !strings.HasPrefix(first, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("Hash() = %q, want argon2id PHC string with current params", first)
+	}
+
+	second, err := hasher.Hash("Passw0rd")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	if first == second {
+		t.Error("Hash() returned equal hashes for two calls, want a random salt")
+	}
+}
+
+func TestHasherVerify(t *testing.T) {
+	current := password.NewHasher(testParams)
+
+	stronger := testParams
+	stronger.Iterations = 2
+
+	argon2Hash, err := current.Hash("Passw0rd")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	oldParamsHash, err := password.NewHasher(stronger).Hash("Passw0rd")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("Passw0rd"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt.GenerateFromPassword() error = %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		password        string
+		encoded         string
+		wantOK          bool
+		wantNeedsRehash bool
+	}{
+		{name: "argon2id match", password: "Passw0rd", encoded: argon2Hash, wantOK: true},
+		{name: "argon2id mismatch", password: "wrong", encoded: argon2Hash},
+		{
+			name:            "argon2id with other params",
+			password:        "Passw0rd",
+			encoded:         oldParamsHash,
+			wantOK:          true,
+			wantNeedsRehash: true,
+		},
+		{
+			name:            "legacy bcrypt match",
+			password:        "Passw0rd",
+			encoded:         string(bcryptHash),
+			wantOK:          true,
+			wantNeedsRehash: true,
+		},
+		{name: "legacy bcrypt mismatch", password: "wrong", encoded: string(bcryptHash)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, needsRehash, err := current.Verify(tt.password, tt.encoded)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+
+			if ok != tt.wantOK || needsRehash != tt.wantNeedsRehash {
+				t.Errorf("Verify() = (%v, %v), want (%v, %v)", ok, needsRehash, tt.wantOK, tt.wantNeedsRehash)
+			}
+		})
+	}
+}
+
+func TestHasherVerifyInvalidHash(t *testing.T) {
+	hasher := password.NewHasher(testParams)
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{name: "empty", encoded: ""},
+		{name: "plain text", encoded: "Passw0rd"},
+		{name: "unknown algorithm", encoded: "$scrypt$ln=15,r=8,p=1$c2FsdA$a2V5"},
+		{name: "missing segments", encoded: "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ"},
+		{name: "other argon2 version", encoded: "$argon2id$v=16$m=1024,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5"},
+		{name: "malformed params", encoded: "$argon2id$v=19$m=x,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5"},
+		{name: "malformed salt", encoded: "$argon2id$v=19$m=1024,t=1,p=1$!!!$a2V5a2V5"},
+		{name: "empty key", encoded: "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ$"},
+		{name: "malformed bcrypt", encoded: "$2a$10$short"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, _, err := hasher.Verify("Passw0rd", tt.encoded)
+			if err == nil {
+				t.Fatal("Verify() error = nil, want an error")
+			}
+
+			if ok {
+				t.Error("Verify() ok = true for an invalid hash")
+			}
+		})
+	}
+}
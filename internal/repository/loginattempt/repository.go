@@ -0,0 +1,129 @@
+// Package loginattempt provides PostgreSQL implementation of the login attempt repository.
+package loginattempt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/based-chat/auth/internal/client/db"
+	"github.com/based-chat/auth/internal/repository"
+	"github.com/jackc/pgx/v4"
+)
+
+var _ repository.LoginAttemptRepository = (*Repository)(nil)
+
+var (
+	errFailedGetLockout      = errors.New("failed to get login lockout")
+	errFailedRegisterFailure = errors.New("failed to register failed login")
+	errFailedResetAttempts   = errors.New("failed to reset failed logins")
+	errFailedDeleteAttempts  = errors.New("failed to delete expired failed logins")
+)
+
+const (
+	queryLockedUntil = `-- name: loginattempt.LockedUntil
+select locked_until
+from login_attempts
+where email_hash = $1`
+
+	// Счётчик увеличивается одним upsert, поэтому параллельные неудачные входы не теряются.
+	// Счётчик обнуляется только при блокировке, так что нулевое значение после вставки или
+	// обновления означает, что блокировку установил этот запрос.
+	queryRegisterFailure = `-- name: loginattempt.RegisterFailure
+insert into login_attempts as a (email_hash, failed_attempts, locked_until, last_failed_at)
+values (
+    $1,
+    case when $2 <= 1 then 0 else 1 end,
+    case when $2 <= 1 then now() + make_interval(secs => $3) end,
+    now()
+)
+on conflict (email_hash) do update
+set last_failed_at = now(),
+    failed_attempts = case when a.failed_attempts + 1 >= $2 then 0 else a.failed_attempts + 1 end,
+    locked_until = case
+        when a.failed_attempts + 1 >= $2 then now() + make_interval(secs => $3)
+        else a.locked_until
+    end
+returning case when failed_attempts = 0 then locked_until end`
+
+	queryReset = `-- name: loginattempt.Reset
+delete from login_attempts
+where email_hash = $1`
+
+	queryDeleteExpired = `-- name: loginattempt.DeleteExpired
+delete from login_attempts
+where greatest(last_failed_at, locked_until) < $1`
+)
+
+// Repository хранит неудачные входы и блокировки в таблице login_attempts.
+type Repository struct {
+	db db.DB
+}
+
+// NewRepository создаёт репозиторий неудачных входов поверх соединения с PostgreSQL.
+func NewRepository(client db.DB) *Repository {
+	return &Repository{db: client}
+}
+
+// LockedUntil возвращает момент снятия блокировки ключа key; нулевое время — блокировки не было.
+func (r *Repository) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	var lockedUntil *time.Time
+
+	err := r.db.QueryRow(ctx, queryLockedUntil, key).Scan(&lockedUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, nil
+	}
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", errFailedGetLockout, err)
+	}
+
+	if lockedUntil == nil {
+		return time.Time{}, nil
+	}
+
+	return *lockedUntil, nil
+}
+
+// RegisterFailure учитывает неудачный вход и при maxAttempts неудачах подряд блокирует
+// ключ на lockout. Возвращает момент снятия блокировки, если её установила эта неудача.
+func (r *Repository) RegisterFailure(
+	ctx context.Context,
+	key string,
+	maxAttempts int,
+	lockout time.Duration,
+) (time.Time, error) {
+	var lockedUntil *time.Time
+
+	err := r.db.QueryRow(ctx, queryRegisterFailure, key, maxAttempts, lockout.Seconds()).Scan(&lockedUntil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", errFailedRegisterFailure, err)
+	}
+
+	if lockedUntil == nil {
+		return time.Time{}, nil
+	}
+
+	return *lockedUntil, nil
+}
+
+// Reset обнуляет счётчик неудач ключа key.
+func (r *Repository) Reset(ctx context.Context, key string) error {
+	if _, err := r.db.Exec(ctx, queryReset, key); err != nil {
+		return fmt.Errorf("%w: %w", errFailedResetAttempts, err)
+	}
+
+	return nil
+}
+
+// DeleteExpired удаляет записи, в которых и последняя неудача, и блокировка раньше before,
+// и возвращает их число.
+func (r *Repository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, queryDeleteExpired, before)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", errFailedDeleteAttempts, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
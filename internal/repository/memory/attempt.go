@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/based-chat/auth/internal/repository"
+)
+
+var _ repository.LoginAttemptRepository = (*LoginAttemptRepository)(nil)
+
+// loginAttempts — неудачные входы по одному ключу.
+type loginAttempts struct {
+	failed      int
+	lockedUntil time.Time
+	lastFailed  time.Time
+}
+
+// LoginAttemptRepository хранит неудачные входы и блокировки в памяти процесса.
+type LoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]*loginAttempts
+}
+
+// NewLoginAttemptRepository создаёт пустое хранилище неудачных входов.
+func NewLoginAttemptRepository() *LoginAttemptRepository {
+	return &LoginAttemptRepository{attempts: make(map[string]*loginAttempts)}
+}
+
+// LockedUntil возвращает момент снятия блокировки ключа key; нулевое время — блокировки не было.
+func (r *LoginAttemptRepository) LockedUntil(_ context.Context, key string) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempts, ok := r.attempts[key]; ok {
+		return attempts.lockedUntil, nil
+	}
+
+	return time.Time{}, nil
+}
+
+// RegisterFailure учитывает неудачный вход и при maxAttempts неудачах подряд блокирует
+// ключ на lockout. Возвращает момент снятия блокировки, если её установила эта неудача.
+func (r *LoginAttemptRepository) RegisterFailure(
+	_ context.Context,
+	key string,
+	maxAttempts int,
+	lockout time.Duration,
+) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts, ok := r.attempts[key]
+	if !ok {
+		attempts = &loginAttempts{}
+		r.attempts[key] = attempts
+	}
+
+	attempts.lastFailed = time.Now()
+
+	attempts.failed++
+	if attempts.failed < maxAttempts {
+		return time.Time{}, nil
+	}
+
+	attempts.failed = 0
+	attempts.lockedUntil = time.Now().Add(lockout)
+
+	return attempts.lockedUntil, nil
+}
+
+// Reset обнуляет счётчик неудач ключа key.
+func (r *LoginAttemptRepository) Reset(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+
+	return nil
+}
+
+// DeleteExpired удаляет записи, в которых и последняя неудача, и блокировка раньше before,
+// и возвращает их число.
+func (r *LoginAttemptRepository) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+
+	for key, attempts := range r.attempts {
+		if attempts.lastFailed.Before(before) && attempts.lockedUntil.Before(before) {
+			delete(r.attempts, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
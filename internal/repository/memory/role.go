@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/repository"
+)
+
+var _ repository.RoleRepository = (*RoleRepository)(nil)
+
+// roles перечисляет роли в порядке их ID в таблице user_role.
+var roles = []model.Role{
+	model.RoleUnspecified,
+	model.RoleAdmin,
+	model.RoleUser,
+	model.RoleModerator,
+	model.RoleSupport,
+}
+
+// RoleRepository хранит разрешения ролей в памяти процесса. Набор ролей фиксирован,
+// а разрешений, как и после миграций, изначально нет.
+type RoleRepository struct {
+	mu          sync.RWMutex
+	permissions map[model.Role][]string
+}
+
+// NewRoleRepository создаёт хранилище ролей без выданных разрешений.
+func NewRoleRepository() *RoleRepository {
+	return &RoleRepository{permissions: make(map[model.Role][]string)}
+}
+
+// List возвращает все роли с их разрешениями в порядке ID.
+func (r *RoleRepository) List(_ context.Context) ([]*model.RoleInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	infos := make([]*model.RoleInfo, 0, len(roles))
+
+	for _, role := range roles {
+		permissions := slices.Clone(r.permissions[role])
+		if permissions == nil {
+			permissions = []string{}
+		}
+
+		slices.Sort(permissions)
+
+		infos = append(infos, &model.RoleInfo{Role: role, Permissions: permissions})
+	}
+
+	return infos, nil
+}
+
+// HasPermission сообщает, выдано ли роли разрешение.
+func (r *RoleRepository) HasPermission(_ context.Context, role model.Role, permission string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Contains(r.permissions[role], permission), nil
+}
+
+// Grant выдаёт роли разрешение. Повторная выдача не считается ошибкой.
+func (r *RoleRepository) Grant(_ context.Context, role model.Role, permission string) error {
+	if !slices.Contains(roles, role) {
+		return repository.ErrRoleNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !slices.Contains(r.permissions[role], permission) {
+		r.permissions[role] = append(r.permissions[role], permission)
+	}
+
+	return nil
+}
+
+// Revoke отзывает у роли разрешение. Отзыв невыданного разрешения не считается ошибкой.
+func (r *RoleRepository) Revoke(_ context.Context, role model.Role, permission string) error {
+	if !slices.Contains(roles, role) {
+		return repository.ErrRoleNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.permissions[role] = slices.DeleteFunc(r.permissions[role], func(p string) bool { return p == permission })
+
+	return nil
+}
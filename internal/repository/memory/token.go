@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/repository"
+)
+
+var _ repository.RefreshTokenRepository = (*RefreshTokenRepository)(nil)
+
+// RefreshTokenRepository хранит хеши refresh-токенов в памяти процесса.
+type RefreshTokenRepository struct {
+	mu     sync.Mutex
+	lastID int64
+	tokens map[string]*model.RefreshToken
+}
+
+// NewRefreshTokenRepository создаёт пустое хранилище refresh-токенов.
+func NewRefreshTokenRepository() *RefreshTokenRepository {
+	return &RefreshTokenRepository{tokens: make(map[string]*model.RefreshToken)}
+}
+
+// Create сохраняет refresh-токен и заполняет его ID и время создания.
+func (r *RefreshTokenRepository) Create(_ context.Context, token *model.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	token.ID = r.lastID
+	token.CreatedAt = time.Now()
+
+	stored := *token
+	r.tokens[stored.TokenHash] = &stored
+
+	return nil
+}
+
+// GetByHash возвращает refresh-токен по хешу или repository.ErrRefreshTokenNotFound, если его нет.
+func (r *RefreshTokenRepository) GetByHash(_ context.Context, hash string) (*model.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[hash]
+	if !ok {
+		return nil, repository.ErrRefreshTokenNotFound
+	}
+
+	copied := *token
+
+	return &copied, nil
+}
+
+// MarkRotated помечает refresh-токен обменянным. Уже обменянный или отозванный токен
+// не меняется, а вызывающий получает repository.ErrRefreshTokenRotated.
+func (r *RefreshTokenRepository) MarkRotated(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.tokens {
+		if token.ID != id {
+			continue
+		}
+
+		if token.RotatedAt != nil || token.RevokedAt != nil {
+			break
+		}
+
+		now := time.Now()
+		token.RotatedAt = &now
+
+		return nil
+	}
+
+	return repository.ErrRefreshTokenRotated
+}
+
+// RevokeFamily отзывает все ещё действующие refresh-токены семейства.
+func (r *RefreshTokenRepository) RevokeFamily(_ context.Context, familyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+
+	return nil
+}
+
+// DeleteExpired удаляет семейства, все токены которых истекли раньше before, и возвращает
+// число удалённых токенов.
+func (r *RefreshTokenRepository) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	live := make(map[string]bool)
+
+	for _, token := range r.tokens {
+		if !token.ExpiresAt.Before(before) {
+			live[token.FamilyID] = true
+		}
+	}
+
+	var deleted int64
+
+	for hash, token := range r.tokens {
+		if !live[token.FamilyID] {
+			delete(r.tokens, hash)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
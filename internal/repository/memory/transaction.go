@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/based-chat/auth/internal/client/db"
+	"github.com/jackc/pgx/v4"
+)
+
+var _ db.TxManager = (*TxManager)(nil)
+
+// TxManager выполняет функции без транзакции. Каждая операция хранилищ в памяти
+// атомарна сама по себе, но несколько операций не откатываются вместе.
+type TxManager struct{}
+
+// NewTxManager создаёт менеджер транзакций для хранилищ в памяти.
+func NewTxManager() *TxManager {
+	return &TxManager{}
+}
+
+// ReadCommitted выполняет fn.
+func (m *TxManager) ReadCommitted(ctx context.Context, fn db.Handler) error {
+	return fn(ctx)
+}
+
+// Serializable выполняет fn.
+func (m *TxManager) Serializable(ctx context.Context, fn db.Handler) error {
+	return fn(ctx)
+}
+
+// Run выполняет fn, не принимая во внимание параметры транзакции.
+func (m *TxManager) Run(ctx context.Context, _ pgx.TxOptions, fn db.Handler) error {
+	return fn(ctx)
+}
@@ -0,0 +1,285 @@
+// Package memory provides in-memory implementations of the repositories for running
+// the service without PostgreSQL.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/repository"
+)
+
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+// UserRepository хранит пользователей в памяти процесса. Как и таблица users, он
+// выдаёт последовательные ID, не допускает двух пользователей с одинаковым без учёта
+// регистра email и проставляет created_at и updated_at.
+type UserRepository struct {
+	mu     sync.RWMutex
+	lastID int64
+	users  map[int64]*model.User
+	emails map[string]int64
+}
+
+// NewUserRepository создаёт пустое хранилище пользователей.
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users:  make(map[int64]*model.User),
+		emails: make(map[string]int64),
+	}
+}
+
+// Create сохраняет пользователя и возвращает присвоенный ему ID.
+func (r *UserRepository) Create(_ context.Context, user *model.User) (int64, error) {
+	if err := checkRole(user.Role); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := emailKey(user.Email)
+	if _, ok := r.emails[key]; ok {
+		return 0, repository.ErrEmailTaken
+	}
+
+	r.lastID++
+	now := time.Now()
+
+	stored := *user
+	stored.ID = r.lastID
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+
+	r.users[stored.ID] = &stored
+	r.emails[key] = stored.ID
+
+	return stored.ID, nil
+}
+
+// Get возвращает пользователя по ID или repository.ErrUserNotFound, если его нет.
+func (r *UserRepository) Get(_ context.Context, id int64) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+
+	return clone(user), nil
+}
+
+// GetByEmail возвращает пользователя по email без учёта регистра
+// или repository.ErrUserNotFound, если его нет.
+func (r *UserRepository) GetByEmail(_ context.Context, email string) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.emails[emailKey(email)]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+
+	return clone(r.users[id]), nil
+}
+
+// UpdatePasswordHash заменяет хеш пароля пользователя.
+func (r *UserRepository) UpdatePasswordHash(_ context.Context, id int64, hash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return repository.ErrUserNotFound
+	}
+
+	user.PasswordHash = hash
+	user.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Update изменяет заданные поля пользователя и возвращает его актуальное состояние.
+func (r *UserRepository) Update(_ context.Context, id int64, update *model.UserUpdate) (*model.User, error) {
+	if update.Role != nil {
+		if err := checkRole(*update.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+
+	if update.Email != nil {
+		key := emailKey(*update.Email)
+		if owner, ok := r.emails[key]; ok && owner != id {
+			return nil, repository.ErrEmailTaken
+		}
+
+		delete(r.emails, emailKey(user.Email))
+		r.emails[key] = id
+		user.Email = *update.Email
+	}
+
+	if update.Name != nil {
+		user.Name = *update.Name
+	}
+
+	if update.Role != nil {
+		user.Role = *update.Role
+	}
+
+	user.UpdatedAt = time.Now()
+
+	return clone(user), nil
+}
+
+// Delete удаляет пользователя по ID.
+func (r *UserRepository) Delete(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return repository.ErrUserNotFound
+	}
+
+	delete(r.emails, emailKey(user.Email))
+	delete(r.users, id)
+
+	return nil
+}
+
+// GetMany возвращает найденных пользователей из ids; отсутствующие ID пропускаются.
+func (r *UserRepository) GetMany(_ context.Context, ids []int64) ([]*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]*model.User, 0, len(ids))
+
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			users = append(users, clone(user))
+		}
+	}
+
+	return users, nil
+}
+
+// List возвращает страницу пользователей, удовлетворяющих фильтру, в порядке query.SortField
+// с ID в качестве второго ключа. Строки сравниваются побайтово, а не по правилам сортировки
+// базы данных.
+func (r *UserRepository) List(_ context.Context, query *model.UserListQuery) ([]*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]*model.User, 0, len(r.users))
+
+	for _, user := range r.users {
+		if matches(user, &query.Filter) {
+			users = append(users, user)
+		}
+	}
+
+	compare := func(a, b *model.User) int {
+		return cmp.Or(compareBy(query.SortField, a, b), cmp.Compare(a.ID, b.ID))
+	}
+
+	if query.Descending {
+		ascending := compare
+		compare = func(a, b *model.User) int { return ascending(b, a) }
+	}
+
+	slices.SortFunc(users, compare)
+
+	start := 0
+
+	if after := query.After; after != nil {
+		cursor := &model.User{ID: after.ID, CreatedAt: after.CreatedAt, Name: after.Name, Email: after.Email}
+		start, _ = slices.BinarySearchFunc(users, cursor, compare)
+
+		for start < len(users) && compare(users[start], cursor) <= 0 {
+			start++
+		}
+	}
+
+	page := make([]*model.User, 0, query.Limit)
+
+	for _, user := range users[start:] {
+		if len(page) == query.Limit {
+			break
+		}
+
+		page = append(page, clone(user))
+	}
+
+	return page, nil
+}
+
+// matches сообщает, удовлетворяет ли пользователь фильтру.
+func matches(user *model.User, filter *model.UserListFilter) bool {
+	if len(filter.Roles) > 0 && !slices.Contains(filter.Roles, user.Role) {
+		return false
+	}
+
+	if filter.CreatedAfter != nil && user.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+
+	if filter.CreatedBefore != nil && !user.CreatedAt.Before(*filter.CreatedBefore) {
+		return false
+	}
+
+	if !strings.HasPrefix(strings.ToLower(user.Email), strings.ToLower(filter.EmailPrefix)) {
+		return false
+	}
+
+	return strings.HasPrefix(strings.ToLower(user.Name), strings.ToLower(filter.NamePrefix))
+}
+
+// compareBy сравнивает пользователей по полю сортировки.
+func compareBy(field model.UserSortField, a, b *model.User) int {
+	switch field {
+	case model.UserSortByCreatedAt:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case model.UserSortByName:
+		return strings.Compare(a.Name, b.Name)
+	case model.UserSortByEmail:
+		return strings.Compare(a.Email, b.Email)
+	default:
+		return cmp.Compare(a.ID, b.ID)
+	}
+}
+
+// emailKey приводит email к виду, в котором проверяется его уникальность.
+func emailKey(email string) string {
+	return strings.ToLower(email)
+}
+
+// clone возвращает копию пользователя, чтобы вызывающий не мог изменить хранимое значение.
+func clone(user *model.User) *model.User {
+	copied := *user
+
+	return &copied
+}
+
+// checkRole отклоняет роль, которой нет в таблице user_role.
+func checkRole(role model.Role) error {
+	if !slices.Contains(roles, role) {
+		return fmt.Errorf("%w: %s", repository.ErrRoleNotFound, role)
+	}
+
+	return nil
+}
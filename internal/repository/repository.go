@@ -0,0 +1,75 @@
+// Package repository describes storage contracts of the auth service.
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/based-chat/auth/internal/model"
+)
+
+var (
+	// ErrUserNotFound возвращается, если пользователь отсутствует в хранилище.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrEmailTaken возвращается, если email без учёта регистра уже принадлежит другому пользователю.
+	ErrEmailTaken = errors.New("email already taken")
+	// ErrRefreshTokenNotFound возвращается, если refresh-токен с указанным хешем не выдавался.
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	// ErrRefreshTokenRotated возвращается при попытке повторно обменять refresh-токен.
+	ErrRefreshTokenRotated = errors.New("refresh token already rotated")
+	// ErrRoleNotFound возвращается, если роли с указанным именем нет в таблице user_role.
+	ErrRoleNotFound = errors.New("role not found")
+)
+
+// UserRepository — хранилище пользователей.
+type UserRepository interface {
+	Create(ctx context.Context, user *model.User) (int64, error)
+	Get(ctx context.Context, id int64) (*model.User, error)
+	GetByEmail(ctx context.Context, email string) (*model.User, error)
+	UpdatePasswordHash(ctx context.Context, id int64, hash string) error
+	Update(ctx context.Context, id int64, update *model.UserUpdate) (*model.User, error)
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, query *model.UserListQuery) ([]*model.User, error)
+	GetMany(ctx context.Context, ids []int64) ([]*model.User, error)
+}
+
+// RefreshTokenRepository — хранилище выданных refresh-токенов.
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *model.RefreshToken) error
+	GetByHash(ctx context.Context, hash string) (*model.RefreshToken, error)
+	// MarkRotated помечает токен обменянным. Если токен уже был обменян или отозван,
+	// возвращает ErrRefreshTokenRotated, что позволяет обнаружить гонку двух обменов.
+	MarkRotated(ctx context.Context, id int64) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	// DeleteExpired удаляет семейства, все токены которых истекли раньше before, и возвращает
+	// число удалённых токенов. Обменянные токены живого семейства остаются, чтобы их повторное
+	// предъявление по-прежнему отзывало семейство.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// RoleRepository — хранилище ролей и выданных им разрешений.
+type RoleRepository interface {
+	List(ctx context.Context) ([]*model.RoleInfo, error)
+	HasPermission(ctx context.Context, role model.Role, permission string) (bool, error)
+	Grant(ctx context.Context, role model.Role, permission string) error
+	Revoke(ctx context.Context, role model.Role, permission string) error
+}
+
+// LoginAttemptRepository — счётчики неудачных входов подряд и блокировки входа.
+// Записи адресуются ключом, производным от email, а не ID пользователя, чтобы попытки
+// входа по адресам без учётной записи учитывались так же, как по существующим.
+type LoginAttemptRepository interface {
+	// LockedUntil возвращает момент снятия блокировки ключа key; нулевое время — блокировки не было.
+	LockedUntil(ctx context.Context, key string) (time.Time, error)
+	// RegisterFailure учитывает неудачный вход. Когда неудач подряд становится maxAttempts,
+	// ключ блокируется на lockout, а счётчик обнуляется. Возвращает момент снятия блокировки,
+	// если её установила именно эта неудача, иначе нулевое время.
+	RegisterFailure(ctx context.Context, key string, maxAttempts int, lockout time.Duration) (time.Time, error)
+	// Reset обнуляет счётчик неудач после успешного входа.
+	Reset(ctx context.Context, key string) error
+	// DeleteExpired удаляет записи, в которых и последняя неудача, и блокировка раньше before,
+	// и возвращает их число. Вызывается с before на срок блокировки в прошлом: неудачи старше
+	// него забываются, а действующая блокировка сохраняется.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,478 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	dbMigrations "github.com/based-chat/auth/db"
+	"github.com/based-chat/auth/internal/client/db/pg"
+	"github.com/based-chat/auth/internal/migrator"
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/repository"
+	loginAttemptRepository "github.com/based-chat/auth/internal/repository/loginattempt"
+	"github.com/based-chat/auth/internal/repository/memory"
+	tokenRepository "github.com/based-chat/auth/internal/repository/token"
+	userRepository "github.com/based-chat/auth/internal/repository/user"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// envTestPostgresDSN — переменная окружения с DSN пустой базы для тестов хранилища PostgreSQL.
+// Без неё тесты выполняются только на хранилище в памяти.
+const envTestPostgresDSN = "TEST_POSTGRES_DSN"
+
+// backend — реализация хранилищ, на которой выполняются общие для всех реализаций тесты.
+type backend struct {
+	name string
+	// users возвращает пустое хранилище пользователей.
+	users func(t *testing.T) repository.UserRepository
+	// refreshTokens возвращает хранилище refresh-токенов; оно очищается вместе с пользователями.
+	refreshTokens func(t *testing.T) repository.RefreshTokenRepository
+	// loginAttempts возвращает пустое хранилище неудачных входов.
+	loginAttempts func(t *testing.T) repository.LoginAttemptRepository
+}
+
+// backends возвращает хранилище в памяти и, если задан TEST_POSTGRES_DSN, PostgreSQL.
+func backends(t *testing.T) []backend {
+	t.Helper()
+
+	list := []backend{{
+		name:  "memory",
+		users: func(*testing.T) repository.UserRepository { return memory.NewUserRepository() },
+		refreshTokens: func(*testing.T) repository.RefreshTokenRepository {
+			return memory.NewRefreshTokenRepository()
+		},
+		loginAttempts: func(*testing.T) repository.LoginAttemptRepository {
+			return memory.NewLoginAttemptRepository()
+		},
+	}}
+
+	dsn := os.Getenv(envTestPostgresDSN)
+	if dsn == "" {
+		return list
+	}
+
+	pool := connect(t, dsn)
+
+	return append(list, backend{
+		name: "postgres",
+		users: func(t *testing.T) repository.UserRepository {
+			t.Helper()
+
+			if _, err := pool.Exec(context.Background(), "truncate users restart identity cascade"); err != nil {
+				t.Fatalf("truncate users: %v", err)
+			}
+
+			return userRepository.NewRepository(pg.NewClient(pool))
+		},
+		refreshTokens: func(*testing.T) repository.RefreshTokenRepository {
+			return tokenRepository.NewRepository(pg.NewClient(pool))
+		},
+		loginAttempts: func(t *testing.T) repository.LoginAttemptRepository {
+			t.Helper()
+
+			if _, err := pool.Exec(context.Background(), "truncate login_attempts"); err != nil {
+				t.Fatalf("truncate login_attempts: %v", err)
+			}
+
+			return loginAttemptRepository.NewRepository(pg.NewClient(pool))
+		},
+	})
+}
+
+// connect применяет миграции к базе dsn и открывает пул соединений, закрываемый по завершении теста.
+func connect(t *testing.T, dsn string) *pgxpool.Pool {
+	t.Helper()
+
+	ctx := context.Background()
+
+	m, err := migrator.New(dsn, dbMigrations.Migrations())
+	if err != nil {
+		t.Fatalf("migrator.New() error = %v", err)
+	}
+
+	defer func() { _ = m.Close() }()
+
+	if err = m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	pool, err := pgxpool.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("pgxpool.Connect() error = %v", err)
+	}
+
+	t.Cleanup(pool.Close)
+
+	return pool
+}
+
+// createUsers сохраняет пользователей names с адресами name@example.com и возвращает их в порядке создания.
+func createUsers(t *testing.T, users repository.UserRepository, names ...string) []*model.User {
+	t.Helper()
+
+	created := make([]*model.User, 0, len(names))
+
+	for _, name := range names {
+		id, err := users.Create(context.Background(), &model.User{
+			Name:         name,
+			Email:        name + "@example.com",
+			PasswordHash: "hash",
+			Role:         model.RoleUser,
+		})
+		if err != nil {
+			t.Fatalf("Create(%q) error = %v", name, err)
+		}
+
+		user, err := users.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Get(%d) error = %v", id, err)
+		}
+
+		created = append(created, user)
+	}
+
+	return created
+}
+
+func TestUserRepositoryEmailUnique(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			users := b.users(t)
+			created := createUsers(t, users, "alice", "bob")
+
+			_, err := users.Create(ctx, &model.User{
+				Name:         "alice",
+				Email:        "Alice@Example.com",
+				PasswordHash: "hash",
+				Role:         model.RoleUser,
+			})
+			if !errors.Is(err, repository.ErrEmailTaken) {
+				t.Errorf("Create() with a taken email error = %v, want %v", err, repository.ErrEmailTaken)
+			}
+
+			email := "ALICE@example.com"
+
+			_, err = users.Update(ctx, created[1].ID, &model.UserUpdate{Email: &email})
+			if !errors.Is(err, repository.ErrEmailTaken) {
+				t.Errorf("Update() to a taken email error = %v, want %v", err, repository.ErrEmailTaken)
+			}
+
+			email = "Bob@Example.com"
+
+			if _, err = users.Update(ctx, created[1].ID, &model.UserUpdate{Email: &email}); err != nil {
+				t.Errorf("Update() to the own email in other case error = %v", err)
+			}
+		})
+	}
+}
+
+func TestUserRepositoryGetByEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		wantErr error
+	}{
+		{name: "same case", email: "alice@example.com"},
+		{name: "upper case", email: "ALICE@EXAMPLE.COM"},
+		{name: "mixed case", email: "Alice@Example.com"},
+		{name: "unknown", email: "bob@example.com", wantErr: repository.ErrUserNotFound},
+	}
+
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			users := b.users(t)
+			alice := createUsers(t, users, "alice")[0]
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					user, err := users.GetByEmail(context.Background(), tt.email)
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("GetByEmail() error = %v, want %v", err, tt.wantErr)
+					}
+
+					if tt.wantErr == nil && user.ID != alice.ID {
+						t.Errorf("GetByEmail() ID = %d, want %d", user.ID, alice.ID)
+					}
+				})
+			}
+
+			if _, err := users.Get(context.Background(), alice.ID+100); !errors.Is(err, repository.ErrUserNotFound) {
+				t.Errorf("Get() of a missing user error = %v, want %v", err, repository.ErrUserNotFound)
+			}
+		})
+	}
+}
+
+func TestUserRepositoryDelete(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			users := b.users(t)
+			alice := createUsers(t, users, "alice")[0]
+
+			if err := users.Delete(ctx, alice.ID); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+
+			if err := users.Delete(ctx, alice.ID); !errors.Is(err, repository.ErrUserNotFound) {
+				t.Errorf("Delete() of a missing user error = %v, want %v", err, repository.ErrUserNotFound)
+			}
+
+			// Адрес удалённого пользователя снова свободен.
+			createUsers(t, users, "alice")
+		})
+	}
+}
+
+func TestUserRepositoryUnknownRole(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			users := b.users(t)
+			alice := createUsers(t, users, "alice")[0]
+			role := model.Role("owner")
+
+			_, err := users.Create(ctx, &model.User{
+				Name:         "bob",
+				Email:        "bob@example.com",
+				PasswordHash: "hash",
+				Role:         role,
+			})
+			if !errors.Is(err, repository.ErrRoleNotFound) {
+				t.Errorf("Create() with an unknown role error = %v, want %v", err, repository.ErrRoleNotFound)
+			}
+
+			_, err = users.Update(ctx, alice.ID, &model.UserUpdate{Role: &role})
+			if !errors.Is(err, repository.ErrRoleNotFound) {
+				t.Errorf("Update() to an unknown role error = %v, want %v", err, repository.ErrRoleNotFound)
+			}
+		})
+	}
+}
+
+func TestUserRepositoryUpdateRole(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			users := b.users(t)
+			alice := createUsers(t, users, "alice")[0]
+			role := model.RoleModerator
+
+			updated, err := users.Update(ctx, alice.ID, &model.UserUpdate{Role: &role})
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			if updated.Role != role {
+				t.Errorf("Update() role = %q, want %q", updated.Role, role)
+			}
+
+			moderators, err := users.List(ctx, &model.UserListQuery{
+				Filter: model.UserListFilter{Roles: []model.Role{role}},
+				Limit:  10,
+			})
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if len(moderators) != 1 || moderators[0].ID != alice.ID {
+				t.Errorf("List() by role = %v, want only user %d", moderators, alice.ID)
+			}
+		})
+	}
+}
+
+func TestUserRepositoryListCursor(t *testing.T) {
+	tests := []struct {
+		name  string
+		query model.UserListQuery
+		want  []string
+	}{
+		{
+			name:  "by id",
+			query: model.UserListQuery{},
+			want:  []string{"carol", "alice", "eve", "bob", "dave"},
+		},
+		{
+			name:  "by created_at descending",
+			query: model.UserListQuery{SortField: model.UserSortByCreatedAt, Descending: true},
+			want:  []string{"dave", "bob", "eve", "alice", "carol"},
+		},
+		{
+			name:  "by name",
+			query: model.UserListQuery{SortField: model.UserSortByName},
+			want:  []string{"alice", "bob", "carol", "dave", "eve"},
+		},
+		{
+			name:  "by email descending",
+			query: model.UserListQuery{SortField: model.UserSortByEmail, Descending: true},
+			want:  []string{"eve", "dave", "carol", "bob", "alice"},
+		},
+		{
+			name: "filtered by name prefix",
+			query: model.UserListQuery{
+				Filter:    model.UserListFilter{NamePrefix: "D"},
+				SortField: model.UserSortByName,
+			},
+			want: []string{"dave"},
+		},
+		{
+			name: "filtered by email prefix",
+			query: model.UserListQuery{
+				Filter:     model.UserListFilter{EmailPrefix: "E"},
+				Descending: true,
+			},
+			want: []string{"eve"},
+		},
+	}
+
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			users := b.users(t)
+			createUsers(t, users, "carol", "alice", "eve", "bob", "dave")
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					if got := listAll(t, users, tt.query, 2); !slices.Equal(got, tt.want) {
+						t.Errorf("List() names = %v, want %v", got, tt.want)
+					}
+				})
+			}
+		})
+	}
+}
+
+// listAll проходит список страницами по pageSize записей, продолжая каждую страницу
+// после последнего пользователя предыдущей, и возвращает имена по порядку.
+func listAll(t *testing.T, users repository.UserRepository, query model.UserListQuery, pageSize int) []string {
+	t.Helper()
+
+	var names []string
+
+	query.Limit = pageSize
+
+	for range 100 {
+		page, err := users.List(context.Background(), &query)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+
+		if len(page) > pageSize {
+			t.Fatalf("List() returned %d users, want at most %d", len(page), pageSize)
+		}
+
+		for _, user := range page {
+			names = append(names, user.Name)
+		}
+
+		if len(page) < pageSize {
+			return names
+		}
+
+		last := page[len(page)-1]
+		query.After = &model.UserCursor{ID: last.ID, CreatedAt: last.CreatedAt, Name: last.Name, Email: last.Email}
+	}
+
+	t.Fatalf("List() did not reach the last page after %v", names)
+
+	return nil
+}
+
+func TestRefreshTokenRepositoryDeleteExpired(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			user := createUsers(t, b.users(t), "alice")[0]
+			tokens := b.refreshTokens(t)
+			now := time.Now()
+
+			create := func(family, hash string, expiresAt time.Time) {
+				t.Helper()
+
+				err := tokens.Create(ctx, &model.RefreshToken{
+					UserID:    user.ID,
+					FamilyID:  family,
+					TokenHash: hash,
+					ExpiresAt: expiresAt,
+				})
+				if err != nil {
+					t.Fatalf("Create(%q) error = %v", hash, err)
+				}
+			}
+
+			// Живое семейство сохраняет истёкший обменянный токен, чтобы распознать его повторное предъявление.
+			create("live", "live-old", now.Add(-2*time.Hour))
+			create("live", "live-new", now.Add(time.Hour))
+			create("expired", "expired-old", now.Add(-3*time.Hour))
+			create("expired", "expired-new", now.Add(-2*time.Hour))
+
+			deleted, err := tokens.DeleteExpired(ctx, now.Add(-time.Hour))
+			if err != nil {
+				t.Fatalf("DeleteExpired() error = %v", err)
+			}
+
+			if deleted != 2 {
+				t.Errorf("DeleteExpired() = %d, want 2", deleted)
+			}
+
+			for hash, want := range map[string]error{
+				"live-old":    nil,
+				"live-new":    nil,
+				"expired-old": repository.ErrRefreshTokenNotFound,
+				"expired-new": repository.ErrRefreshTokenNotFound,
+			} {
+				if _, err := tokens.GetByHash(ctx, hash); !errors.Is(err, want) {
+					t.Errorf("GetByHash(%q) error = %v, want %v", hash, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginAttemptRepositoryDeleteExpired(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			attempts := b.loginAttempts(t)
+
+			if _, err := attempts.RegisterFailure(ctx, "failed", 2, time.Hour); err != nil {
+				t.Fatalf("RegisterFailure(failed) error = %v", err)
+			}
+
+			for range 2 {
+				if _, err := attempts.RegisterFailure(ctx, "locked", 2, time.Hour); err != nil {
+					t.Fatalf("RegisterFailure(locked) error = %v", err)
+				}
+			}
+
+			// Обе неудачи уже в прошлом, но блокировка ключа locked ещё действует.
+			deleted, err := attempts.DeleteExpired(ctx, time.Now().Add(time.Minute))
+			if err != nil {
+				t.Fatalf("DeleteExpired() error = %v", err)
+			}
+
+			if deleted != 1 {
+				t.Errorf("DeleteExpired() = %d, want 1", deleted)
+			}
+
+			if until, err := attempts.LockedUntil(ctx, "locked"); err != nil || until.IsZero() {
+				t.Errorf("LockedUntil(locked) = %v, %v, want the lockout kept", until, err)
+			}
+
+			// Счётчик удалённого ключа начинается заново: одна неудача не блокирует.
+			until, err := attempts.RegisterFailure(ctx, "failed", 2, time.Hour)
+			if err != nil {
+				t.Fatalf("RegisterFailure(failed) error = %v", err)
+			}
+
+			if !until.IsZero() {
+				t.Errorf("RegisterFailure(failed) locked until %v, want the counter reset", until)
+			}
+		})
+	}
+}
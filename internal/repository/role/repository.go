@@ -0,0 +1,157 @@
+// Package role provides PostgreSQL implementation of the role repository.
+package role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/based-chat/auth/internal/client/db"
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/repository"
+	"github.com/jackc/pgx/v4"
+)
+
+var _ repository.RoleRepository = (*Repository)(nil)
+
+var (
+	errFailedListRoles        = errors.New("failed to list roles")
+	errFailedCheckPermission  = errors.New("failed to check permission")
+	errFailedGrantPermission  = errors.New("failed to grant permission")
+	errFailedRevokePermission = errors.New("failed to revoke permission")
+)
+
+const (
+	queryList = `-- name: role.List
+select r.name, coalesce(array_agg(p.name order by p.name) filter (where p.name is not null), '{}')
+from user_role r
+left join role_permissions rp on rp.role_id = r.id
+left join permissions p on p.id = rp.permission_id
+group by r.id, r.name
+order by r.id`
+
+	queryHasPermission = `-- name: role.HasPermission
+select exists (
+    select 1
+    from role_permissions rp
+    join user_role r on r.id = rp.role_id
+    join permissions p on p.id = rp.permission_id
+    where r.name = $1 and p.name = $2
+)`
+
+	queryRoleID = `-- name: role.RoleID
+select id from user_role where name = $1`
+
+	queryGrant = `-- name: role.Grant
+with p as (
+    insert into permissions (name) values ($2)
+    on conflict (name) do update set name = excluded.name
+    returning id
+)
+insert into role_permissions (role_id, permission_id)
+select $1, p.id from p
+on conflict do nothing`
+
+	queryRevoke = `-- name: role.Revoke
+delete from role_permissions rp
+using permissions p
+where rp.permission_id = p.id and rp.role_id = $1 and p.name = $2`
+)
+
+// Repository хранит роли в таблице user_role и их разрешения в role_permissions.
+type Repository struct {
+	db db.DB
+}
+
+// NewRepository создаёт репозиторий ролей поверх соединения с PostgreSQL.
+func NewRepository(client db.DB) *Repository {
+	return &Repository{db: client}
+}
+
+// List возвращает все роли с их разрешениями в порядке ID.
+func (r *Repository) List(ctx context.Context) ([]*model.RoleInfo, error) {
+	rows, err := r.db.Query(ctx, queryList)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedListRoles, err)
+	}
+	defer rows.Close()
+
+	var roles []*model.RoleInfo
+
+	for rows.Next() {
+		var (
+			name        string
+			permissions []string
+		)
+
+		if err := rows.Scan(&name, &permissions); err != nil {
+			return nil, fmt.Errorf("%w: %w", errFailedListRoles, err)
+		}
+
+		roles = append(roles, &model.RoleInfo{
+			Role:        model.Role(name),
+			Permissions: permissions,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedListRoles, err)
+	}
+
+	return roles, nil
+}
+
+// HasPermission сообщает, выдано ли роли разрешение.
+func (r *Repository) HasPermission(ctx context.Context, role model.Role, permission string) (bool, error) {
+	var ok bool
+
+	if err := r.db.QueryRow(ctx, queryHasPermission, string(role), permission).Scan(&ok); err != nil {
+		return false, fmt.Errorf("%w: %w", errFailedCheckPermission, err)
+	}
+
+	return ok, nil
+}
+
+// Grant выдаёт роли разрешение, заводя его при необходимости. Повторная выдача не считается ошибкой.
+func (r *Repository) Grant(ctx context.Context, role model.Role, permission string) error {
+	roleID, err := r.roleID(ctx, role)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errFailedGrantPermission, err)
+	}
+
+	if _, err := r.db.Exec(ctx, queryGrant, roleID, permission); err != nil {
+		return fmt.Errorf("%w: %w", errFailedGrantPermission, err)
+	}
+
+	return nil
+}
+
+// Revoke отзывает у роли разрешение. Отзыв невыданного разрешения не считается ошибкой.
+func (r *Repository) Revoke(ctx context.Context, role model.Role, permission string) error {
+	roleID, err := r.roleID(ctx, role)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errFailedRevokePermission, err)
+	}
+
+	if _, err := r.db.Exec(ctx, queryRevoke, roleID, permission); err != nil {
+		return fmt.Errorf("%w: %w", errFailedRevokePermission, err)
+	}
+
+	return nil
+}
+
+// roleID находит ID роли по имени или возвращает repository.ErrRoleNotFound.
+func (r *Repository) roleID(ctx context.Context, role model.Role) (int64, error) {
+	var id int64
+
+	err := r.db.QueryRow(ctx, queryRoleID, string(role)).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, repository.ErrRoleNotFound
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,136 @@
+// Package token provides PostgreSQL implementation of the refresh token repository.
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/based-chat/auth/internal/client/db"
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/repository"
+	"github.com/jackc/pgx/v4"
+)
+
+var _ repository.RefreshTokenRepository = (*Repository)(nil)
+
+var (
+	errFailedCreateToken = errors.New("failed to create refresh token")
+	errFailedGetToken    = errors.New("failed to get refresh token")
+	errFailedRotateToken = errors.New("failed to rotate refresh token")
+	errFailedRevokeToken = errors.New("failed to revoke refresh token family")
+	errFailedDeleteToken = errors.New("failed to delete expired refresh tokens")
+)
+
+const (
+	queryCreate = `-- name: token.Create
+insert into refresh_tokens (user_id, family_id, token_hash, expires_at)
+values ($1, $2, $3, $4)
+returning id, created_at`
+
+	queryGetByHash = `-- name: token.GetByHash
+select id, user_id, family_id, token_hash, expires_at, created_at, rotated_at, revoked_at
+from refresh_tokens
+where token_hash = $1`
+
+	queryMarkRotated = `-- name: token.MarkRotated
+update refresh_tokens
+set rotated_at = now()
+where id = $1 and rotated_at is null and revoked_at is null`
+
+	queryRevokeFamily = `-- name: token.RevokeFamily
+update refresh_tokens
+set revoked_at = now()
+where family_id = $1 and revoked_at is null`
+
+	queryDeleteExpired = `-- name: token.DeleteExpired
+delete from refresh_tokens t
+where t.expires_at < $1
+  and not exists (
+    select 1 from refresh_tokens live
+    where live.family_id = t.family_id and live.expires_at >= $1
+  )`
+)
+
+// Repository хранит хеши refresh-токенов в таблице refresh_tokens.
+type Repository struct {
+	db db.DB
+}
+
+// NewRepository создаёт репозиторий refresh-токенов поверх соединения с PostgreSQL.
+func NewRepository(client db.DB) *Repository {
+	return &Repository{db: client}
+}
+
+// Create сохраняет refresh-токен и заполняет его ID и время создания.
+func (r *Repository) Create(ctx context.Context, token *model.RefreshToken) error {
+	err := r.db.QueryRow(ctx, queryCreate, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt).
+		Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errFailedCreateToken, err)
+	}
+
+	return nil
+}
+
+// GetByHash возвращает refresh-токен по хешу или repository.ErrRefreshTokenNotFound, если его нет.
+func (r *Repository) GetByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+
+	err := r.db.QueryRow(ctx, queryGetByHash, hash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.RotatedAt,
+		&token.RevokedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %w", errFailedGetToken, repository.ErrRefreshTokenNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedGetToken, err)
+	}
+
+	return &token, nil
+}
+
+// MarkRotated помечает refresh-токен обменянным.
+// Обновление выполняется только для ещё не обменянного и не отозванного токена, поэтому из двух
+// одновременных обменов успешен лишь один; второй получает repository.ErrRefreshTokenRotated.
+func (r *Repository) MarkRotated(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, queryMarkRotated, id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errFailedRotateToken, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %w", errFailedRotateToken, repository.ErrRefreshTokenRotated)
+	}
+
+	return nil
+}
+
+// RevokeFamily отзывает все ещё действующие refresh-токены семейства.
+func (r *Repository) RevokeFamily(ctx context.Context, familyID string) error {
+	if _, err := r.db.Exec(ctx, queryRevokeFamily, familyID); err != nil {
+		return fmt.Errorf("%w: %w", errFailedRevokeToken, err)
+	}
+
+	return nil
+}
+
+// DeleteExpired удаляет семейства, все токены которых истекли раньше before, и возвращает
+// число удалённых токенов.
+func (r *Repository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, queryDeleteExpired, before)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", errFailedDeleteToken, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,371 @@
+// Package user provides PostgreSQL implementation of the user repository.
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/based-chat/auth/internal/client/db"
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/repository"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+var _ repository.UserRepository = (*Repository)(nil)
+
+var (
+	errFailedCreateUser = errors.New("failed to create user")
+	errFailedGetUser    = errors.New("failed to get user")
+	errFailedUpdateUser = errors.New("failed to update user")
+	errFailedDeleteUser = errors.New("failed to delete user")
+	errFailedUpdateHash = errors.New("failed to update password hash")
+	errFailedListUsers  = errors.New("failed to list users")
+	errFailedGetMany    = errors.New("failed to get users")
+)
+
+const (
+	// uniqueViolation — код ошибки PostgreSQL при нарушении уникального индекса.
+	uniqueViolation = "23505"
+
+	// userColumns читает роль по имени: ID строк user_role задаются миграциями и в коде не используются.
+	userColumns = "u.id, u.name, u.email, u.password, " +
+		"(select r.name from user_role r where r.id = u.role), u.created_at, u.updated_at"
+
+	queryCreate = `-- name: user.Create
+insert into users (name, email, password, role)
+select $1, $2, $3, r.id from user_role r where r.name = $4
+returning id`
+
+	queryRoleID = `-- name: user.RoleID
+select id from user_role where name = $1`
+
+	queryGet = `-- name: user.Get
+select ` + userColumns + ` from users u where u.id = $1`
+
+	queryGetByEmail = `-- name: user.GetByEmail
+select ` + userColumns + ` from users u where lower(u.email) = lower($1)`
+
+	queryUpdatePasswordHash = `-- name: user.UpdatePasswordHash
+update users set password = $2 where id = $1`
+
+	queryUpdate = `-- name: user.Update
+update users u
+set name = coalesce($2, u.name),
+    email = coalesce($3, u.email),
+    role = coalesce($4, u.role)
+where u.id = $1
+returning ` + userColumns
+
+	queryDelete = `-- name: user.Delete
+delete from users where id = $1`
+
+	queryGetMany = `-- name: user.GetMany
+select ` + userColumns + ` from users u where u.id = any($1)`
+
+	queryList = `-- name: user.List
+select ` + userColumns + ` from users u`
+)
+
+// sortColumns сопоставляет полям сортировки колонки запроса.
+var sortColumns = map[model.UserSortField]string{
+	model.UserSortByID:        "u.id",
+	model.UserSortByCreatedAt: "u.created_at",
+	model.UserSortByName:      "u.name",
+	model.UserSortByEmail:     "u.email",
+}
+
+// Repository хранит пользователей в таблице users.
+type Repository struct {
+	db db.DB
+}
+
+// NewRepository создаёт репозиторий пользователей поверх соединения с PostgreSQL.
+func NewRepository(client db.DB) *Repository {
+	return &Repository{db: client}
+}
+
+// Create сохраняет пользователя и возвращает присвоенный ему ID.
+func (r *Repository) Create(ctx context.Context, user *model.User) (int64, error) {
+	var id int64
+
+	err := r.db.QueryRow(ctx, queryCreate, user.Name, user.Email, user.PasswordHash, string(user.Role)).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%w: %w: %s", errFailedCreateUser, repository.ErrRoleNotFound, user.Role)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", errFailedCreateUser, mapUniqueViolation(err))
+	}
+
+	return id, nil
+}
+
+// Get возвращает пользователя по ID или repository.ErrUserNotFound, если его нет.
+func (r *Repository) Get(ctx context.Context, id int64) (*model.User, error) {
+	user, err := scanUser(r.db.QueryRow(ctx, queryGet, id))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedGetUser, err)
+	}
+
+	return user, nil
+}
+
+// GetByEmail возвращает пользователя по email или repository.ErrUserNotFound, если его нет.
+func (r *Repository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	user, err := scanUser(r.db.QueryRow(ctx, queryGetByEmail, email))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedGetUser, err)
+	}
+
+	return user, nil
+}
+
+// UpdatePasswordHash заменяет хеш пароля пользователя.
+func (r *Repository) UpdatePasswordHash(ctx context.Context, id int64, hash string) error {
+	tag, err := r.db.Exec(ctx, queryUpdatePasswordHash, id, hash)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errFailedUpdateHash, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %w", errFailedUpdateHash, repository.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// Update изменяет заданные поля пользователя и возвращает его актуальное состояние.
+func (r *Repository) Update(ctx context.Context, id int64, update *model.UserUpdate) (*model.User, error) {
+	var roleID *int32
+
+	if update.Role != nil {
+		value, err := r.roleID(ctx, *update.Role)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errFailedUpdateUser, err)
+		}
+
+		roleID = &value
+	}
+
+	user, err := scanUser(r.db.QueryRow(ctx, queryUpdate, id, update.Name, update.Email, roleID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedUpdateUser, mapUniqueViolation(err))
+	}
+
+	return user, nil
+}
+
+// Delete удаляет пользователя по ID или возвращает repository.ErrUserNotFound, если его нет.
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, queryDelete, id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errFailedDeleteUser, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %w", errFailedDeleteUser, repository.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// GetMany возвращает пользователей с указанными ID одним запросом. Отсутствующие ID
+// пропускаются, порядок результата не определён.
+func (r *Repository) GetMany(ctx context.Context, ids []int64) ([]*model.User, error) {
+	rows, err := r.db.Query(ctx, queryGetMany, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedGetMany, err)
+	}
+	defer rows.Close()
+
+	users := make([]*model.User, 0, len(ids))
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errFailedGetMany, err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedGetMany, err)
+	}
+
+	return users, nil
+}
+
+// List возвращает страницу пользователей, удовлетворяющих фильтру, в порядке query.SortField
+// с ID в качестве второго ключа. Страница начинается сразу после query.After и содержит
+// не больше query.Limit записей.
+func (r *Repository) List(ctx context.Context, query *model.UserListQuery) ([]*model.User, error) {
+	column, ok := sortColumns[query.SortField]
+	if !ok {
+		column = sortColumns[model.UserSortByID]
+	}
+
+	var (
+		sql   strings.Builder
+		conds []string
+		args  []any
+	)
+
+	arg := func(value any) string {
+		args = append(args, value)
+
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	filter := query.Filter
+
+	if len(filter.Roles) > 0 {
+		roles := make([]string, 0, len(filter.Roles))
+		for _, role := range filter.Roles {
+			roles = append(roles, string(role))
+		}
+
+		conds = append(conds, "u.role in (select r.id from user_role r where r.name = any("+arg(roles)+"))")
+	}
+
+	if filter.CreatedAfter != nil {
+		conds = append(conds, "u.created_at >= "+arg(*filter.CreatedAfter))
+	}
+
+	if filter.CreatedBefore != nil {
+		conds = append(conds, "u.created_at < "+arg(*filter.CreatedBefore))
+	}
+
+	if filter.EmailPrefix != "" {
+		conds = append(conds, "lower(u.email) like "+arg(likePrefix(filter.EmailPrefix)))
+	}
+
+	if filter.NamePrefix != "" {
+		conds = append(conds, "lower(u.name) like "+arg(likePrefix(filter.NamePrefix)))
+	}
+
+	direction, cmp := "asc", ">"
+	if query.Descending {
+		direction, cmp = "desc", "<"
+	}
+
+	if after := query.After; after != nil {
+		if column == sortColumns[model.UserSortByID] {
+			conds = append(conds, column+" "+cmp+" "+arg(after.ID))
+		} else {
+			conds = append(conds, fmt.Sprintf("(%s, u.id) %s (%s, %s)",
+				column, cmp, arg(cursorValue(query.SortField, after)), arg(after.ID)))
+		}
+	}
+
+	sql.WriteString(queryList)
+
+	if len(conds) > 0 {
+		sql.WriteString("\nwhere ")
+		sql.WriteString(strings.Join(conds, "\n  and "))
+	}
+
+	sql.WriteString("\norder by " + column + " " + direction)
+
+	if column != sortColumns[model.UserSortByID] {
+		sql.WriteString(", u.id " + direction)
+	}
+
+	sql.WriteString("\nlimit " + arg(query.Limit))
+
+	rows, err := r.db.Query(ctx, sql.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedListUsers, err)
+	}
+	defer rows.Close()
+
+	users := make([]*model.User, 0, query.Limit)
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errFailedListUsers, err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedListUsers, err)
+	}
+
+	return users, nil
+}
+
+// cursorValue возвращает значение колонки сортировки из курсора.
+func cursorValue(field model.UserSortField, after *model.UserCursor) any {
+	switch field {
+	case model.UserSortByCreatedAt:
+		return after.CreatedAt
+	case model.UserSortByName:
+		return after.Name
+	case model.UserSortByEmail:
+		return after.Email
+	default:
+		return after.ID
+	}
+}
+
+// likePrefix строит регистронезависимый LIKE-шаблон поиска по префиксу,
+// экранируя спецсимволы LIKE в самом префиксе.
+func likePrefix(prefix string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(prefix))
+
+	return escaped + "%"
+}
+
+// scanUser читает строку пользователя, подменяя pgx.ErrNoRows на repository.ErrUserNotFound.
+func scanUser(row pgx.Row) (*model.User, error) {
+	var (
+		user model.User
+		role string
+	)
+
+	err := row.Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &role, &user.CreatedAt, &user.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrUserNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	user.Role = model.Role(role)
+
+	return &user, nil
+}
+
+// roleID находит ID роли по имени или возвращает repository.ErrRoleNotFound.
+func (r *Repository) roleID(ctx context.Context, role model.Role) (int32, error) {
+	var id int32
+
+	err := r.db.QueryRow(ctx, queryRoleID, string(role)).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%w: %s", repository.ErrRoleNotFound, role)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// mapUniqueViolation подменяет нарушение уникального индекса по email на repository.ErrEmailTaken.
+func mapUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repository.ErrEmailTaken
+	}
+
+	return err
+}
@@ -0,0 +1,94 @@
+// Package access implements access checks for other services of the chat stack.
+package access
+
+import (
+	"context"
+	"errors"
+
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/repository"
+	"github.com/based-chat/auth/internal/service"
+	"github.com/based-chat/auth/internal/token"
+)
+
+var _ service.AccessService = (*Service)(nil)
+
+// Service проверяет access-токены и права их владельцев.
+//
+// Разрешение — это полное имя gRPC-метода или произвольное имя, о котором договорились
+// сервисы. Разрешения выдаются ролям и хранятся в role_permissions; администратору доступно всё,
+// а не выданные разрешения закрыты для остальных ролей. Разрешения описывают доступ только
+// к другим сервисам стека: методы самого сервиса авторизации закрыты правилами
+// interceptor.Rules и через role_permissions не настраиваются.
+type Service struct {
+	userRepository repository.UserRepository
+	roleRepository repository.RoleRepository
+	tokens         *token.Manager
+}
+
+// NewService создаёт сервис проверки доступа.
+func NewService(
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	tokens *token.Manager,
+) *Service {
+	return &Service{
+		userRepository: userRepository,
+		roleRepository: roleRepository,
+		tokens:         tokens,
+	}
+}
+
+// Check проверяет access-токен и решает, разрешён ли его владельцу endpoint.
+// Роль берётся из текущего состояния пользователя, а не из токена, поэтому понижение роли
+// действует сразу, не дожидаясь истечения уже выданных токенов.
+// Недействительный токен — ошибка service.ErrInvalidAccessToken; запрет — решение с Allowed=false.
+func (s *Service) Check(ctx context.Context, accessToken, endpoint string) (*model.AccessDecision, error) {
+	claims, err := s.tokens.ParseAccessToken(accessToken)
+	if err != nil {
+		return nil, service.ErrInvalidAccessToken
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, service.ErrInvalidAccessToken
+	}
+
+	user, err := s.userRepository.Get(ctx, userID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, service.ErrInvalidAccessToken
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := user.Role == model.RoleAdmin
+	if !allowed {
+		allowed, err = s.roleRepository.HasPermission(ctx, user.Role, endpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.AccessDecision{
+		Allowed: allowed,
+		UserID:  user.ID,
+		Role:    user.Role,
+	}, nil
+}
+
+// ListRoles возвращает все роли с выданными им разрешениями.
+func (s *Service) ListRoles(ctx context.Context) ([]*model.RoleInfo, error) {
+	return s.roleRepository.List(ctx)
+}
+
+// GrantPermission выдаёт роли разрешение.
+func (s *Service) GrantPermission(ctx context.Context, role model.Role, permission string) error {
+	return s.roleRepository.Grant(ctx, role, permission)
+}
+
+// RevokePermission отзывает у роли разрешение.
+func (s *Service) RevokePermission(ctx context.Context, role model.Role, permission string) error {
+	return s.roleRepository.Revoke(ctx, role, permission)
+}
@@ -0,0 +1,320 @@
+// Package auth implements user sign-in and session management.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/based-chat/auth/internal/client/db"
+	"github.com/based-chat/auth/internal/metrics"
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/password"
+	"github.com/based-chat/auth/internal/repository"
+	"github.com/based-chat/auth/internal/service"
+	"github.com/based-chat/auth/internal/token"
+)
+
+var _ service.AuthService = (*Service)(nil)
+
+var (
+	errFailedRehashPassword = errors.New("failed to rehash password")
+	errLoginLocked          = errors.New("login locked after failed attempts")
+)
+
+// Lockout — правило блокировки входа по email после неудачных попыток.
+type Lockout struct {
+	// MaxAttempts — число неудачных входов подряд, после которого вход блокируется.
+	MaxAttempts int
+	// Duration — срок блокировки.
+	Duration time.Duration
+}
+
+// Service выполняет вход по email и паролю и выдаёт пары токенов.
+type Service struct {
+	userRepository    repository.UserRepository
+	tokenRepository   repository.RefreshTokenRepository
+	attemptRepository repository.LoginAttemptRepository
+	hasher            *password.Hasher
+	tokens            *token.Manager
+	txManager         db.TxManager
+	lockout           Lockout
+	metrics           *metrics.Auth
+}
+
+// NewService создаёт сервис аутентификации.
+func NewService(
+	userRepository repository.UserRepository,
+	tokenRepository repository.RefreshTokenRepository,
+	attemptRepository repository.LoginAttemptRepository,
+	hasher *password.Hasher,
+	tokens *token.Manager,
+	txManager db.TxManager,
+	lockout Lockout,
+	metrics *metrics.Auth,
+) *Service {
+	return &Service{
+		userRepository:    userRepository,
+		tokenRepository:   tokenRepository,
+		attemptRepository: attemptRepository,
+		hasher:            hasher,
+		tokens:            tokens,
+		txManager:         txManager,
+		lockout:           lockout,
+		metrics:           metrics,
+	}
+}
+
+// Login проверяет email и пароль и выдаёт новую пару токенов.
+// Если хеш пароля создан устаревшим алгоритмом или параметрами, он прозрачно пересчитывается.
+// После Lockout.MaxAttempts неверных паролей подряд вход по этому email блокируется на
+// Lockout.Duration. Неверный пароль всегда отклоняется с service.ErrInvalidCredentials, а о блокировке
+// (*service.LockedError) узнаёт только тот, кто предъявил верный пароль. Неудачи по адресам без
+// учётной записи учитываются так же, поэтому ответы на вход не выдают, существует ли email.
+func (s *Service) Login(ctx context.Context, email, password string) (*model.User, *model.Tokens, error) {
+	user, tokens, err := s.login(ctx, email, password)
+	s.metrics.Login(outcome(err, service.ErrInvalidCredentials, service.ErrAccountLocked))
+
+	return user, tokens, err
+}
+
+// login выполняет вход без учёта в метриках.
+func (s *Service) login(ctx context.Context, email, password string) (*model.User, *model.Tokens, error) {
+	key := attemptKey(email)
+
+	user, err := s.userRepository.GetByEmail(ctx, email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		// Хешируем пароль впустую, чтобы время ответа не выдавало существование email.
+		_, _ = s.hasher.Hash(password)
+
+		return nil, nil, s.registerFailure(ctx, key)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ok, needsRehash, err := s.hasher.Verify(password, user.PasswordHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !ok {
+		return nil, nil, s.registerFailure(ctx, key)
+	}
+
+	lockedUntil, err := s.attemptRepository.LockedUntil(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if time.Now().Before(lockedUntil) {
+		return nil, nil, &service.LockedError{Until: lockedUntil}
+	}
+
+	if err = s.attemptRepository.Reset(ctx, key); err != nil {
+		return nil, nil, err
+	}
+
+	if needsRehash {
+		s.rehash(ctx, user.ID, password)
+	}
+
+	familyID, err := token.NewFamilyID()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.issueTokens(ctx, user, familyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, tokens, nil
+}
+
+// Refresh обменивает refresh-токен на новую пару токенов. Предъявленный токен перестаёт
+// действовать, а новый наследует его семейство. Повторное предъявление уже обменянного токена
+// считается утечкой: всё семейство отзывается, и вызывающий получает service.ErrRefreshTokenReused.
+// Роль в access-токене берётся из текущего состояния пользователя.
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (*model.Tokens, error) {
+	tokens, err := s.refresh(ctx, refreshToken)
+	s.metrics.Refresh(outcome(err, service.ErrInvalidRefreshToken, service.ErrRefreshTokenReused))
+
+	return tokens, err
+}
+
+// refresh выполняет обмен refresh-токена без учёта в метриках.
+func (s *Service) refresh(ctx context.Context, refreshToken string) (*model.Tokens, error) {
+	stored, err := s.tokenRepository.GetByHash(ctx, token.HashRefreshToken(refreshToken))
+	if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+		return nil, service.ErrInvalidRefreshToken
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.RotatedAt != nil {
+		return nil, s.revokeReusedFamily(ctx, stored)
+	}
+
+	if stored.RevokedAt != nil || !stored.ExpiresAt.After(time.Now()) {
+		return nil, service.ErrInvalidRefreshToken
+	}
+
+	var tokens *model.Tokens
+
+	// Обмен выполняется в одной транзакции: если новый токен выпустить не удалось,
+	// старый остаётся действующим и клиент может повторить запрос.
+	err = s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		if err := s.tokenRepository.MarkRotated(ctx, stored.ID); err != nil {
+			return err
+		}
+
+		user, err := s.userRepository.Get(ctx, stored.UserID)
+		if err != nil {
+			return err
+		}
+
+		tokens, err = s.issueTokens(ctx, user, stored.FamilyID)
+
+		return err
+	})
+
+	switch {
+	case errors.Is(err, repository.ErrRefreshTokenRotated):
+		// Токен обменяли параллельно с нами — это такое же повторное использование.
+		return nil, s.revokeReusedFamily(ctx, stored)
+	case errors.Is(err, repository.ErrUserNotFound):
+		return nil, service.ErrInvalidRefreshToken
+	case err != nil:
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Logout завершает сессию: отзывает всё семейство, к которому принадлежит refresh-токен.
+// Повторный выход с тем же токеном не считается ошибкой.
+func (s *Service) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := s.tokenRepository.GetByHash(ctx, token.HashRefreshToken(refreshToken))
+	if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+		return service.ErrInvalidRefreshToken
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return s.tokenRepository.RevokeFamily(ctx, stored.FamilyID)
+}
+
+// issueTokens выпускает access-токен и сохраняет новый refresh-токен пользователя в семействе familyID.
+func (s *Service) issueTokens(ctx context.Context, user *model.User, familyID string) (*model.Tokens, error) {
+	accessToken, accessExpiresAt, err := s.tokens.IssueAccessToken(user)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshHash, refreshExpiresAt, err := s.tokens.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tokenRepository.Create(ctx, &model.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: refreshHash,
+		ExpiresAt: refreshExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Tokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+// revokeReusedFamily отзывает семейство повторно предъявленного refresh-токена
+// и возвращает service.ErrRefreshTokenReused либо ошибку отзыва.
+func (s *Service) revokeReusedFamily(ctx context.Context, stored *model.RefreshToken) error {
+	slog.WarnContext(ctx, service.ErrRefreshTokenReused.Error(),
+		slog.Int64("user_id", stored.UserID),
+		slog.String("family_id", stored.FamilyID),
+	)
+
+	if err := s.tokenRepository.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return err
+	}
+
+	s.metrics.Lockout(metrics.LockoutRefreshTokenReuse)
+
+	return service.ErrRefreshTokenReused
+}
+
+// registerFailure учитывает неверный пароль для ключа key и возвращает
+// service.ErrInvalidCredentials либо ошибку хранилища. Установленная этой неудачей блокировка
+// попадает в журнал и метрики, но на ответ не влияет.
+func (s *Service) registerFailure(ctx context.Context, key string) error {
+	lockedUntil, err := s.attemptRepository.RegisterFailure(ctx, key, s.lockout.MaxAttempts, s.lockout.Duration)
+	if err != nil {
+		return err
+	}
+
+	if !lockedUntil.IsZero() {
+		slog.WarnContext(ctx, errLoginLocked.Error(),
+			slog.String("attempt_key", key),
+			slog.Time("locked_until", lockedUntil),
+		)
+
+		s.metrics.Lockout(metrics.LockoutFailedLogins)
+	}
+
+	return service.ErrInvalidCredentials
+}
+
+// attemptKey возвращает ключ учёта неудачных входов для email: SHA-256 адреса в нижнем регистре,
+// так же, как email сравнивается при поиске пользователя.
+func attemptKey(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// outcome возвращает результат операции для метрик: отказ по одной из ошибок rejections
+// или сбой по любой другой ошибке.
+func outcome(err error, rejections ...error) string {
+	if err == nil {
+		return metrics.ResultSuccess
+	}
+
+	for _, rejection := range rejections {
+		if errors.Is(err, rejection) {
+			return metrics.ResultFailure
+		}
+	}
+
+	return metrics.ResultError
+}
+
+// rehash пересчитывает хеш пароля с текущими параметрами.
+// Ошибка не прерывает вход: пользователь уже подтвердил пароль, а хеш обновится при следующем входе.
+func (s *Service) rehash(ctx context.Context, userID int64, password string) {
+	hash, err := s.hasher.Hash(password)
+	if err == nil {
+		err = s.userRepository.UpdatePasswordHash(ctx, userID, hash)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, errFailedRehashPassword.Error(), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,407 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/based-chat/auth/internal/keys"
+	"github.com/based-chat/auth/internal/metrics"
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/password"
+	"github.com/based-chat/auth/internal/repository/memory"
+	"github.com/based-chat/auth/internal/service"
+	"github.com/based-chat/auth/internal/service/auth"
+	"github.com/based-chat/auth/internal/token"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	testEmail    = "user@example.com"
+	testPassword = "Passw0rd"
+)
+
+// testParams — дешёвые параметры argon2id, чтобы тесты шли быстро.
+var testParams = password.Params{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// fixture — сервис аутентификации поверх хранилищ в памяти.
+type fixture struct {
+	service *auth.Service
+	users   *memory.UserRepository
+	hasher  *password.Hasher
+}
+
+// newFixture создаёт сервис с блокировкой после maxAttempts неудачных входов.
+func newFixture(t *testing.T, maxAttempts int) *fixture {
+	t.Helper()
+
+	key, err := keys.Generate()
+	if err != nil {
+		t.Fatalf("keys.Generate() error = %v", err)
+	}
+
+	keySet, err := keys.NewSet(key)
+	if err != nil {
+		t.Fatalf("keys.NewSet() error = %v", err)
+	}
+
+	f := &fixture{
+		users:  memory.NewUserRepository(),
+		hasher: password.NewHasher(testParams),
+	}
+
+	f.service = auth.NewService(
+		f.users,
+		memory.NewRefreshTokenRepository(),
+		memory.NewLoginAttemptRepository(),
+		f.hasher,
+		token.NewManager("auth", "based-chat", time.Minute, time.Hour, keySet),
+		memory.NewTxManager(),
+		auth.Lockout{MaxAttempts: maxAttempts, Duration: time.Minute},
+		metrics.NewAuth(prometheus.NewRegistry()),
+	)
+
+	return f
+}
+
+// createUser сохраняет пользователя с хешем пароля passwordHash и возвращает его ID.
+func (f *fixture) createUser(t *testing.T, email, passwordHash string) int64 {
+	t.Helper()
+
+	id, err := f.users.Create(context.Background(), &model.User{
+		Name:         "user",
+		Email:        email,
+		PasswordHash: passwordHash,
+		Role:         model.RoleUser,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	return id
+}
+
+// hash возвращает argon2id-хеш пароля.
+func (f *fixture) hash(t *testing.T, plain string) string {
+	t.Helper()
+
+	hash, err := f.hasher.Hash(plain)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	return hash
+}
+
+// login входит от имени testEmail и возвращает выданные токены.
+func (f *fixture) login(t *testing.T) *model.Tokens {
+	t.Helper()
+
+	_, tokens, err := f.service.Login(context.Background(), testEmail, testPassword)
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	return tokens
+}
+
+func TestLogin(t *testing.T) {
+	f := newFixture(t, 100)
+	id := f.createUser(t, testEmail, f.hash(t, testPassword))
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		wantErr  error
+	}{
+		{name: "valid credentials", email: testEmail, password: testPassword},
+		{name: "email in other case", email: strings.ToUpper(testEmail), password: testPassword},
+		{name: "wrong password", email: testEmail, password: "wrong", wantErr: service.ErrInvalidCredentials},
+		{
+			name:     "unknown email",
+			email:    "nobody@example.com",
+			password: testPassword,
+			wantErr:  service.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, tokens, err := f.service.Login(context.Background(), tt.email, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Login() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if user.ID != id {
+				t.Errorf("Login() user ID = %d, want %d", user.ID, id)
+			}
+
+			if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+				t.Error("Login() returned empty tokens")
+			}
+		})
+	}
+}
+
+func TestLoginRehashesLegacyHash(t *testing.T) {
+	f := newFixture(t, 100)
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt.GenerateFromPassword() error = %v", err)
+	}
+
+	id := f.createUser(t, testEmail, string(legacy))
+	f.login(t)
+
+	user, err := f.users.Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if !strings.HasPrefix(user.PasswordHash, "$argon2id$") {
+		t.Errorf("password hash after login = %q, want argon2id", user.PasswordHash)
+	}
+
+	f.login(t)
+}
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// run выполняет сценарий и возвращает ошибку последнего обмена.
+		run     func(t *testing.T, f *fixture) error
+		wantErr error
+	}{
+		{
+			name: "fresh token is rotated",
+			run: func(t *testing.T, f *fixture) error {
+				issued := f.login(t)
+
+				rotated, err := f.service.Refresh(ctx, issued.RefreshToken)
+				if err == nil && rotated.RefreshToken == issued.RefreshToken {
+					t.Error("Refresh() returned the presented refresh token")
+				}
+
+				return err
+			},
+		},
+		{
+			name: "unknown token",
+			run: func(_ *testing.T, f *fixture) error {
+				_, err := f.service.Refresh(ctx, "unknown")
+
+				return err
+			},
+			wantErr: service.ErrInvalidRefreshToken,
+		},
+		{
+			name: "rotated token is reused",
+			run: func(t *testing.T, f *fixture) error {
+				issued := f.login(t)
+
+				if _, err := f.service.Refresh(ctx, issued.RefreshToken); err != nil {
+					t.Fatalf("Refresh() error = %v", err)
+				}
+
+				_, err := f.service.Refresh(ctx, issued.RefreshToken)
+
+				return err
+			},
+			wantErr: service.ErrRefreshTokenReused,
+		},
+		{
+			name: "reuse revokes the whole family",
+			run: func(t *testing.T, f *fixture) error {
+				issued := f.login(t)
+
+				rotated, err := f.service.Refresh(ctx, issued.RefreshToken)
+				if err != nil {
+					t.Fatalf("Refresh() error = %v", err)
+				}
+
+				if _, err = f.service.Refresh(ctx, issued.RefreshToken); !errors.Is(err, service.ErrRefreshTokenReused) {
+					t.Fatalf("Refresh() of reused token error = %v, want %v", err, service.ErrRefreshTokenReused)
+				}
+
+				_, err = f.service.Refresh(ctx, rotated.RefreshToken)
+
+				return err
+			},
+			wantErr: service.ErrInvalidRefreshToken,
+		},
+		{
+			name: "other sessions survive reuse",
+			run: func(t *testing.T, f *fixture) error {
+				leaked := f.login(t)
+				other := f.login(t)
+
+				if _, err := f.service.Refresh(ctx, leaked.RefreshToken); err != nil {
+					t.Fatalf("Refresh() error = %v", err)
+				}
+
+				if _, err := f.service.Refresh(ctx, leaked.RefreshToken); !errors.Is(err, service.ErrRefreshTokenReused) {
+					t.Fatalf("Refresh() of reused token error = %v, want %v", err, service.ErrRefreshTokenReused)
+				}
+
+				_, err := f.service.Refresh(ctx, other.RefreshToken)
+
+				return err
+			},
+		},
+		{
+			name: "token after logout",
+			run: func(t *testing.T, f *fixture) error {
+				issued := f.login(t)
+
+				if err := f.service.Logout(ctx, issued.RefreshToken); err != nil {
+					t.Fatalf("Logout() error = %v", err)
+				}
+
+				_, err := f.service.Refresh(ctx, issued.RefreshToken)
+
+				return err
+			},
+			wantErr: service.ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, 100)
+			f.createUser(t, testEmail, f.hash(t, testPassword))
+
+			if err := tt.run(t, f); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Refresh() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t, 100)
+	f.createUser(t, testEmail, f.hash(t, testPassword))
+
+	issued := f.login(t)
+
+	if err := f.service.Logout(ctx, issued.RefreshToken); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+
+	if err := f.service.Logout(ctx, issued.RefreshToken); err != nil {
+		t.Errorf("repeated Logout() error = %v, want nil", err)
+	}
+
+	if err := f.service.Logout(ctx, "unknown"); !errors.Is(err, service.ErrInvalidRefreshToken) {
+		t.Errorf("Logout() of unknown token error = %v, want %v", err, service.ErrInvalidRefreshToken)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	const maxAttempts = 3
+
+	tests := []struct {
+		name string
+		// failures — адреса, с которых по очереди вводится неверный пароль.
+		failures []string
+		email    string
+		password string
+		wantErr  error
+	}{
+		{
+			name:     "correct password below the limit",
+			failures: []string{testEmail, testEmail},
+			email:    testEmail,
+			password: testPassword,
+		},
+		{
+			name:     "wrong password while locked",
+			failures: []string{testEmail, testEmail, testEmail},
+			email:    testEmail,
+			password: "wrong",
+			wantErr:  service.ErrInvalidCredentials,
+		},
+		{
+			name:     "correct password while locked",
+			failures: []string{testEmail, testEmail, testEmail},
+			email:    testEmail,
+			password: testPassword,
+			wantErr:  service.ErrAccountLocked,
+		},
+		{
+			name:     "email case does not split the counter",
+			failures: []string{testEmail, strings.ToUpper(testEmail), "User@Example.com"},
+			email:    testEmail,
+			password: testPassword,
+			wantErr:  service.ErrAccountLocked,
+		},
+		{
+			name:     "unknown email is indistinguishable",
+			failures: []string{"nobody@example.com", "nobody@example.com", "nobody@example.com"},
+			email:    "nobody@example.com",
+			password: testPassword,
+			wantErr:  service.ErrInvalidCredentials,
+		},
+		{
+			name:     "other email is not locked",
+			failures: []string{"nobody@example.com", "nobody@example.com", "nobody@example.com"},
+			email:    testEmail,
+			password: testPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newFixture(t, maxAttempts)
+			f.createUser(t, testEmail, f.hash(t, testPassword))
+
+			for _, email := range tt.failures {
+				if _, _, err := f.service.Login(ctx, email, "wrong"); !errors.Is(err, service.ErrInvalidCredentials) {
+					t.Fatalf("Login() with wrong password error = %v, want %v", err, service.ErrInvalidCredentials)
+				}
+			}
+
+			_, _, err := f.service.Login(ctx, tt.email, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Login() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var locked *service.LockedError
+			if errors.As(err, &locked) && !locked.Until.After(time.Now()) {
+				t.Errorf("LockedError.Until = %v, want a moment in the future", locked.Until)
+			}
+		})
+	}
+}
+
+func TestLoginResetsFailures(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t, 2)
+	f.createUser(t, testEmail, f.hash(t, testPassword))
+
+	for range 3 {
+		if _, _, err := f.service.Login(ctx, testEmail, "wrong"); !errors.Is(err, service.ErrInvalidCredentials) {
+			t.Fatalf("Login() with wrong password error = %v, want %v", err, service.ErrInvalidCredentials)
+		}
+
+		f.login(t)
+	}
+}
@@ -0,0 +1,72 @@
+// Package service describes business logic contracts of the auth service.
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/based-chat/auth/internal/model"
+)
+
+var (
+	// ErrInvalidCredentials возвращается, если пары email/пароль нет среди пользователей.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrInvalidRefreshToken возвращается для неизвестного, отозванного или истёкшего refresh-токена.
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused возвращается при повторном предъявлении уже обменянного refresh-токена.
+	// К этому моменту всё семейство токенов уже отозвано.
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+	// ErrInvalidAccessToken возвращается для access-токена с неверной подписью, истёкшим сроком
+	// или принадлежащего удалённому пользователю.
+	ErrInvalidAccessToken = errors.New("invalid access token")
+	// ErrInvalidPageToken возвращается для повреждённого токена страницы или токена,
+	// выданного для другого фильтра или порядка сортировки.
+	ErrInvalidPageToken = errors.New("invalid page token")
+	// ErrAccountLocked возвращается при входе с верным паролем, если вход по этому email
+	// заблокирован после серии неудачных попыток. Срок блокировки передаётся в *LockedError.
+	ErrAccountLocked = errors.New("account locked")
+)
+
+// LockedError сообщает, до какого момента заблокирована учётная запись.
+// errors.Is(err, ErrAccountLocked) для неё истинно.
+type LockedError struct {
+	// Until — момент снятия блокировки.
+	Until time.Time
+}
+
+// Error возвращает текст ошибки со сроком блокировки.
+func (e *LockedError) Error() string {
+	return ErrAccountLocked.Error() + " until " + e.Until.UTC().Format(time.RFC3339)
+}
+
+// Is сопоставляет ошибку с ErrAccountLocked.
+func (e *LockedError) Is(target error) bool {
+	return target == ErrAccountLocked
+}
+
+// UserService управляет учётными записями пользователей.
+type UserService interface {
+	Create(ctx context.Context, user *model.User, password string) (int64, error)
+	Get(ctx context.Context, id int64) (*model.User, error)
+	Update(ctx context.Context, id int64, update *model.UserUpdate) (*model.User, error)
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, query *model.UserListQuery, pageToken string) ([]*model.User, string, error)
+	GetMany(ctx context.Context, ids []int64) (found []*model.User, missing []int64, err error)
+}
+
+// AuthService выполняет вход пользователей и управляет их сессиями.
+type AuthService interface {
+	Login(ctx context.Context, email, password string) (*model.User, *model.Tokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.Tokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+}
+
+// AccessService решает, разрешён ли владельцу access-токена вызов эндпоинта,
+// и управляет разрешениями ролей.
+type AccessService interface {
+	Check(ctx context.Context, accessToken, endpoint string) (*model.AccessDecision, error)
+	ListRoles(ctx context.Context) ([]*model.RoleInfo, error)
+	GrantPermission(ctx context.Context, role model.Role, permission string) error
+	RevokePermission(ctx context.Context, role model.Role, permission string) error
+}
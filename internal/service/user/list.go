@@ -0,0 +1,190 @@
+package user
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/service"
+)
+
+const (
+	// DefaultPageSize — размер страницы, если клиент его не указал.
+	DefaultPageSize = 50
+	// MaxPageSize — наибольший размер страницы; большие значения урезаются до него.
+	MaxPageSize = 200
+)
+
+var errPageTokenTooShort = errors.New("page token is too short")
+
+// listToken — содержимое непрозрачного токена страницы. Токен зашифрован ключом сервера,
+// поэтому клиент не видит значений курсора и не может подделать его.
+type listToken struct {
+	// Query — отпечаток фильтра и сортировки, для которых выдан токен.
+	Query string `json:"q"`
+	// ID — ID последнего пользователя предыдущей страницы.
+	ID int64 `json:"i"`
+	// Value — значение колонки сортировки у этого пользователя; при сортировке по ID пусто.
+	Value string `json:"v,omitempty"`
+}
+
+// List возвращает страницу пользователей и токен следующей страницы.
+// query.After заполняется из pageToken; пустой токен следующей страницы означает, что она последняя.
+func (s *Service) List(
+	ctx context.Context,
+	query *model.UserListQuery,
+	pageToken string,
+) ([]*model.User, string, error) {
+	if query.SortField == "" {
+		query.SortField = model.UserSortByID
+	}
+
+	switch {
+	case query.Limit <= 0:
+		query.Limit = DefaultPageSize
+	case query.Limit > MaxPageSize:
+		query.Limit = MaxPageSize
+	}
+
+	fingerprint := queryFingerprint(query)
+
+	if pageToken != "" {
+		after, err := s.decodePageToken(pageToken, query.SortField, fingerprint)
+		if err != nil {
+			return nil, "", err
+		}
+
+		query.After = after
+	}
+
+	pageSize := query.Limit
+	// Запрашиваем на одну запись больше, чтобы узнать, есть ли следующая страница.
+	query.Limit++
+
+	users, err := s.userRepository.List(ctx, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(users) <= pageSize {
+		return users, "", nil
+	}
+
+	users = users[:pageSize]
+	last := users[pageSize-1]
+
+	next, err := s.encodePageToken(fingerprint, query.SortField, last)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return users, next, nil
+}
+
+// queryFingerprint вычисляет отпечаток фильтра и сортировки запроса.
+// Размер страницы в отпечаток не входит: его можно менять между страницами.
+func queryFingerprint(query *model.UserListQuery) string {
+	// Маршалинг структуры из строк, чисел и времени не может завершиться ошибкой.
+	raw, _ := json.Marshal(struct {
+		Filter     model.UserListFilter
+		SortField  model.UserSortField
+		Descending bool
+	}{query.Filter, query.SortField, query.Descending})
+
+	sum := sha256.Sum256(raw)
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// encodePageToken упаковывает в токен страницы ID пользователя last и значение колонки
+// сортировки field и шифрует его AES-GCM.
+func (s *Service) encodePageToken(fingerprint string, field model.UserSortField, last *model.User) (string, error) {
+	token := listToken{Query: fingerprint, ID: last.ID}
+
+	switch field {
+	case model.UserSortByCreatedAt:
+		token.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	case model.UserSortByName:
+		token.Value = last.Name
+	case model.UserSortByEmail:
+		token.Value = last.Email
+	}
+
+	// Маршалинг структуры из строк и чисел не может завершиться ошибкой.
+	raw, _ := json.Marshal(token)
+
+	nonce := make([]byte, s.pageTokenCipher.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(s.pageTokenCipher.Seal(nonce, nonce, raw, nil)), nil
+}
+
+// decodePageToken расшифровывает токен страницы, проверяет, что он выдан для того же запроса,
+// и восстанавливает из него курсор для сортировки field.
+func (s *Service) decodePageToken(
+	token string,
+	field model.UserSortField,
+	fingerprint string,
+) (*model.UserCursor, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPageToken, err)
+	}
+
+	nonceSize := s.pageTokenCipher.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPageToken, errPageTokenTooShort)
+	}
+
+	raw, err := s.pageTokenCipher.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPageToken, err)
+	}
+
+	var decoded listToken
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("%w: %w", service.ErrInvalidPageToken, err)
+	}
+
+	if decoded.Query != fingerprint {
+		return nil, service.ErrInvalidPageToken
+	}
+
+	cursor := &model.UserCursor{ID: decoded.ID}
+
+	switch field {
+	case model.UserSortByCreatedAt:
+		cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, decoded.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", service.ErrInvalidPageToken, err)
+		}
+	case model.UserSortByName:
+		cursor.Name = decoded.Value
+	case model.UserSortByEmail:
+		cursor.Email = decoded.Value
+	}
+
+	return cursor, nil
+}
+
+// newPageTokenCipher создаёт AES-256-GCM с ключом, выведенным из секрета key.
+func newPageTokenCipher(key []byte) cipher.AEAD {
+	sum := sha256.Sum256(key)
+
+	// Ключ SHA-256 всегда подходит AES-256, а GCM — блочному шифру со 128-битным блоком.
+	block, _ := aes.NewCipher(sum[:])
+	aead, _ := cipher.NewGCM(block)
+
+	return aead
+}
@@ -0,0 +1,261 @@
+package user_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/service"
+	"github.com/based-chat/auth/internal/service/user"
+)
+
+// listRepository отдаёт пользователей users в порядке ID и запоминает запросы страниц.
+type listRepository struct {
+	userRepository
+
+	users   []*model.User
+	queries []model.UserListQuery
+}
+
+// newListRepository создаёт хранилище с пользователями names, ID которых идут по порядку с 1,
+// а адреса имеют вид name@example.com.
+func newListRepository(names ...string) *listRepository {
+	r := &listRepository{}
+
+	for i, name := range names {
+		r.users = append(r.users, &model.User{
+			ID:        int64(i + 1),
+			Name:      name,
+			Email:     name + "@example.com",
+			CreatedAt: time.Date(2025, 10, 1, 0, 0, i, 0, time.UTC),
+		})
+	}
+
+	return r
+}
+
+// tamper возвращает токен с изменённым байтом в середине.
+func tamper(t *testing.T, token string) string {
+	t.Helper()
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		t.Fatalf("decode page token: %v", err)
+	}
+
+	raw[len(raw)/2] ^= 0xff
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func (r *listRepository) List(_ context.Context, query *model.UserListQuery) ([]*model.User, error) {
+	r.queries = append(r.queries, *query)
+
+	start := 0
+	if query.After != nil {
+		start = int(query.After.ID)
+	}
+
+	end := min(start+query.Limit, len(r.users))
+
+	return r.users[start:end], nil
+}
+
+func TestListPages(t *testing.T) {
+	users := newListRepository("alice", "bob", "carol", "dave", "eve")
+	s := newService(users)
+
+	var (
+		names     []string
+		pageToken string
+	)
+
+	for page := 0; ; page++ {
+		if page > len(users.users) {
+			t.Fatal("List() did not reach the last page")
+		}
+
+		got, next, err := s.List(context.Background(), &model.UserListQuery{Limit: 2}, pageToken)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+
+		for _, u := range got {
+			names = append(names, u.Name)
+		}
+
+		if next == "" {
+			break
+		}
+
+		pageToken = next
+	}
+
+	if want := []string{"alice", "bob", "carol", "dave", "eve"}; !slices.Equal(names, want) {
+		t.Errorf("List() names = %v, want %v", names, want)
+	}
+
+	// Каждая следующая страница запрашивается после последнего пользователя предыдущей.
+	for i, query := range users.queries[1:] {
+		if want := int64(2 * (i + 1)); query.After == nil || query.After.ID != want {
+			t.Errorf("page %d cursor = %+v, want ID %d", i+2, query.After, want)
+		}
+	}
+}
+
+func TestListPageTokenCursor(t *testing.T) {
+	tests := []struct {
+		field model.UserSortField
+		want  model.UserCursor
+	}{
+		{field: model.UserSortByID, want: model.UserCursor{ID: 1}},
+		{field: model.UserSortByName, want: model.UserCursor{ID: 1, Name: "alice"}},
+		{field: model.UserSortByEmail, want: model.UserCursor{ID: 1, Email: "alice@example.com"}},
+		{
+			field: model.UserSortByCreatedAt,
+			want:  model.UserCursor{ID: 1, CreatedAt: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.field), func(t *testing.T) {
+			users := newListRepository("alice", "bob")
+			s := newService(users)
+
+			_, next, err := s.List(context.Background(), &model.UserListQuery{SortField: tt.field, Limit: 1}, "")
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			// Токен непрозрачен: данные пользователя в нём не читаются.
+			raw, err := base64.RawURLEncoding.DecodeString(next)
+			if err != nil {
+				t.Fatalf("decode page token: %v", err)
+			}
+
+			if bytes.Contains(raw, []byte("alice")) {
+				t.Errorf("page token %q exposes the user's name or email", next)
+			}
+
+			query := &model.UserListQuery{SortField: tt.field, Limit: 1}
+			if _, _, err = s.List(context.Background(), query, next); err != nil {
+				t.Fatalf("List() with the page token error = %v", err)
+			}
+
+			// В курсоре только ID и значение колонки сортировки.
+			if got := users.queries[1].After; got == nil || *got != tt.want {
+				t.Errorf("cursor = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPageSize(t *testing.T) {
+	names := make([]string, user.MaxPageSize+1)
+	for i := range names {
+		names[i] = fmt.Sprintf("user%03d", i)
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{name: "default", limit: 0, want: user.DefaultPageSize},
+		{name: "negative", limit: -1, want: user.DefaultPageSize},
+		{name: "explicit", limit: 7, want: 7},
+		{name: "above maximum", limit: user.MaxPageSize + 1, want: user.MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newListRepository(names...)
+
+			got, next, err := newService(users).List(context.Background(), &model.UserListQuery{Limit: tt.limit}, "")
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if len(got) != tt.want {
+				t.Errorf("List() returned %d users, want %d", len(got), tt.want)
+			}
+
+			// Хранилище просят на одну запись больше, чтобы узнать о следующей странице.
+			if limit := users.queries[0].Limit; limit != tt.want+1 {
+				t.Errorf("repository limit = %d, want %d", limit, tt.want+1)
+			}
+
+			if next == "" {
+				t.Error("List() next page token is empty, want more pages")
+			}
+		})
+	}
+}
+
+func TestListInvalidPageToken(t *testing.T) {
+	s := newService(newListRepository("alice", "bob", "carol"))
+
+	byNameQuery := model.UserListQuery{SortField: model.UserSortByName}
+
+	_, byName, err := s.List(context.Background(), &model.UserListQuery{SortField: model.UserSortByName, Limit: 1}, "")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	// Тот же запрос к сервису с другим ключом выдаёт токен, который этот сервис не примет.
+	_, otherKey, err := newServiceWithKey(newListRepository("alice", "bob"), "other key").
+		List(context.Background(), &model.UserListQuery{SortField: model.UserSortByName, Limit: 1}, "")
+	if err != nil {
+		t.Fatalf("List() with another key error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		query     model.UserListQuery
+		pageToken string
+	}{
+		{name: "not base64", query: model.UserListQuery{}, pageToken: "!!!"},
+		{name: "not encrypted", query: model.UserListQuery{}, pageToken: "bm90IGVuY3J5cHRlZCBieSB0aGUgc2VydmVy"},
+		{name: "too short", query: model.UserListQuery{}, pageToken: "YQ"},
+		{name: "tampered", query: byNameQuery, pageToken: tamper(t, byName)},
+		{name: "other key", query: byNameQuery, pageToken: otherKey},
+		{name: "other sort field", query: model.UserListQuery{SortField: model.UserSortByEmail}, pageToken: byName},
+		{
+			name:      "other sort order",
+			query:     model.UserListQuery{SortField: model.UserSortByName, Descending: true},
+			pageToken: byName,
+		},
+		{
+			name: "other filter",
+			query: model.UserListQuery{
+				Filter:    model.UserListFilter{NamePrefix: "a"},
+				SortField: model.UserSortByName,
+			},
+			pageToken: byName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := s.List(context.Background(), &tt.query, tt.pageToken)
+			if !errors.Is(err, service.ErrInvalidPageToken) {
+				t.Errorf("List() error = %v, want %v", err, service.ErrInvalidPageToken)
+			}
+		})
+	}
+
+	users, _, err := s.List(context.Background(), &model.UserListQuery{SortField: model.UserSortByName, Limit: 5}, byName)
+	if err != nil {
+		t.Fatalf("List() with another page size error = %v", err)
+	}
+
+	if len(users) != 2 || users[0].Name != "bob" {
+		t.Errorf("List() with another page size returned %d users, want bob and carol", len(users))
+	}
+}
@@ -0,0 +1,110 @@
+// Package user implements the user account service.
+package user
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/password"
+	"github.com/based-chat/auth/internal/repository"
+	"github.com/based-chat/auth/internal/service"
+)
+
+var _ service.UserService = (*Service)(nil)
+
+// pageTokenKeySize — длина случайного ключа токенов страниц, если ключ не задан.
+const pageTokenKeySize = 32
+
+// Service управляет пользователями поверх репозитория.
+type Service struct {
+	userRepository  repository.UserRepository
+	hasher          *password.Hasher
+	pageTokenCipher cipher.AEAD
+}
+
+// NewService создаёт сервис пользователей. Токены страниц списка шифруются ключом pageTokenKey;
+// при пустом ключе он генерируется случайно, и выданные токены не переживут перезапуск
+// и не подойдут другим экземплярам сервиса.
+func NewService(userRepository repository.UserRepository, hasher *password.Hasher, pageTokenKey []byte) *Service {
+	if len(pageTokenKey) == 0 {
+		pageTokenKey = make([]byte, pageTokenKeySize)
+		_, _ = rand.Read(pageTokenKey)
+	}
+
+	return &Service{
+		userRepository:  userRepository,
+		hasher:          hasher,
+		pageTokenCipher: newPageTokenCipher(pageTokenKey),
+	}
+}
+
+// Create создает нового пользователя и возвращает его ID. Пароль сохраняется только в виде argon2id-хеша.
+// Пользователь без указанной роли получает model.RoleUser: у роли unspecified нет разрешений.
+func (s *Service) Create(ctx context.Context, user *model.User, password string) (int64, error) {
+	if user.Role == "" || user.Role == model.RoleUnspecified {
+		user.Role = model.RoleUser
+	}
+
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return 0, err
+	}
+
+	user.PasswordHash = hash
+
+	return s.userRepository.Create(ctx, user)
+}
+
+// Get возвращает пользователя по ID.
+func (s *Service) Get(ctx context.Context, id int64) (*model.User, error) {
+	return s.userRepository.Get(ctx, id)
+}
+
+// Update обновляет переданные поля пользователя и возвращает его актуальное состояние.
+func (s *Service) Update(ctx context.Context, id int64, update *model.UserUpdate) (*model.User, error) {
+	return s.userRepository.Update(ctx, id, update)
+}
+
+// Delete удаляет пользователя.
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	return s.userRepository.Delete(ctx, id)
+}
+
+// GetMany возвращает найденных пользователей в порядке первого упоминания их ID
+// и список ID, которых нет в хранилище. Повторяющиеся ID учитываются один раз.
+func (s *Service) GetMany(ctx context.Context, ids []int64) ([]*model.User, []int64, error) {
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]struct{}, len(ids))
+
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			unique = append(unique, id)
+		}
+	}
+
+	users, err := s.userRepository.GetMany(ctx, unique)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[int64]*model.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	found := make([]*model.User, 0, len(users))
+	missing := make([]int64, 0, len(unique)-len(users))
+
+	for _, id := range unique {
+		if user, ok := byID[id]; ok {
+			found = append(found, user)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	return found, missing, nil
+}
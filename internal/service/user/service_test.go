@@ -0,0 +1,78 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/password"
+	"github.com/based-chat/auth/internal/repository"
+	"github.com/based-chat/auth/internal/service/user"
+)
+
+// userRepository — хранилище пользователей теста. Методы, которые тесты не вызывают,
+// не реализованы.
+type userRepository struct {
+	repository.UserRepository
+
+	created []*model.User
+}
+
+func (r *userRepository) Create(_ context.Context, user *model.User) (int64, error) {
+	stored := *user
+	r.created = append(r.created, &stored)
+
+	return int64(len(r.created)), nil
+}
+
+// newService создаёт сервис пользователей поверх хранилища users.
+func newService(users repository.UserRepository) *user.Service {
+	return newServiceWithKey(users, "page token key")
+}
+
+// newServiceWithKey создаёт сервис с дешёвыми параметрами argon2id и ключом токенов страниц key.
+func newServiceWithKey(users repository.UserRepository, key string) *user.Service {
+	return user.NewService(users, password.NewHasher(password.Params{
+		Memory:      1024,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	}), []byte(key))
+}
+
+func TestCreateRole(t *testing.T) {
+	tests := []struct {
+		name string
+		role model.Role
+		want model.Role
+	}{
+		{name: "zero value", role: "", want: model.RoleUser},
+		{name: "unspecified", role: model.RoleUnspecified, want: model.RoleUser},
+		{name: "user", role: model.RoleUser, want: model.RoleUser},
+		{name: "admin", role: model.RoleAdmin, want: model.RoleAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &userRepository{}
+
+			_, err := newService(users).Create(context.Background(), &model.User{
+				Name:  "user",
+				Email: "user@example.com",
+				Role:  tt.role,
+			}, "Passw0rd")
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			if got := users.created[0].Role; got != tt.want {
+				t.Errorf("Create() role = %q, want %q", got, tt.want)
+			}
+
+			if users.created[0].PasswordHash == "Passw0rd" {
+				t.Error("Create() stored the plain password")
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Package token issues and parses access tokens and generates refresh tokens.
+//
+// Access tokens are JWTs carrying the user ID in the sub claim and the user role in
+// the role claim. Refresh tokens are opaque random strings; only their SHA-256 hash
+// is meant to be persisted.
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/based-chat/auth/internal/keys"
+	"github.com/based-chat/auth/internal/model"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const refreshTokenLength = 32
+
+var (
+	// ErrInvalidToken возвращается для токена с неверной подписью, истёкшим сроком или чужими iss/aud.
+	ErrInvalidToken = errors.New("invalid token")
+
+	errFailedSignToken     = errors.New("failed to sign token")
+	errAlgorithmMismatch   = errors.New("token algorithm does not match key")
+	errFailedGenerateToken = errors.New("failed to generate random token")
+)
+
+// Claims — полезная нагрузка access-токена.
+type Claims struct {
+	jwt.RegisteredClaims
+
+	Role model.Role `json:"role"`
+}
+
+// UserID возвращает ID пользователя из claim sub.
+func (c *Claims) UserID() (int64, error) {
+	id, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return id, nil
+}
+
+// Manager подписывает и проверяет access-токены и создаёт refresh-токены.
+type Manager struct {
+	issuer     string
+	audience   string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	keys       *keys.Set
+	now        func() time.Time
+}
+
+// NewManager создаёт Manager. Access-токены подписываются активным ключом набора keySet,
+// а проверяются любым ключом набора по kid из заголовка.
+func NewManager(issuer, audience string, accessTTL, refreshTTL time.Duration, keySet *keys.Set) *Manager {
+	return &Manager{
+		issuer:     issuer,
+		audience:   audience,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+		keys:       keySet,
+		now:        time.Now,
+	}
+}
+
+// IssueAccessToken выпускает access-токен для пользователя и возвращает его вместе со временем истечения.
+func (m *Manager) IssueAccessToken(user *model.User) (string, time.Time, error) {
+	now := m.now()
+	expiresAt := now.Add(m.accessTTL)
+
+	jti, err := randomString()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    m.issuer,
+			Subject:   strconv.FormatInt(user.ID, 10),
+			Audience:  jwt.ClaimStrings{m.audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Role: user.Role,
+	}
+
+	key := m.keys.Active()
+
+	unsigned := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	unsigned.Header["kid"] = key.ID
+
+	signed, err := unsigned.SignedString(key.Private)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%w: %w", errFailedSignToken, err)
+	}
+
+	return signed, expiresAt, nil
+}
+
+// ParseAccessToken проверяет подпись, срок действия, iss и aud access-токена и возвращает его claims.
+func (m *Manager) ParseAccessToken(raw string) (*Claims, error) {
+	claims := &Claims{}
+
+	_, err := jwt.ParseWithClaims(raw, claims, m.verificationKey,
+		jwt.WithValidMethods([]string{keys.AlgorithmEdDSA, keys.AlgorithmRS256}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithAudience(m.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return claims, nil
+}
+
+// verificationKey находит открытый ключ по kid токена и проверяет, что алгоритм
+// в заголовке совпадает с алгоритмом ключа.
+func (m *Manager) verificationKey(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	key, err := m.keys.Lookup(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("%w: %s", errAlgorithmMismatch, t.Method.Alg())
+	}
+
+	return key.Public, nil
+}
+
+// NewRefreshToken генерирует случайный refresh-токен.
+// Возвращает сам токен для клиента, его хеш для хранения и время истечения.
+func (m *Manager) NewRefreshToken() (raw, hash string, expiresAt time.Time, err error) {
+	raw, err = randomString()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	return raw, HashRefreshToken(raw), m.now().Add(m.refreshTTL), nil
+}
+
+// NewFamilyID генерирует идентификатор семейства refresh-токенов, начинающегося с нового входа.
+func NewFamilyID() (string, error) {
+	return randomString()
+}
+
+// HashRefreshToken возвращает хеш refresh-токена, под которым он хранится в базе.
+// Токен содержит 256 бит энтропии, поэтому соль и медленный хеш не нужны.
+func HashRefreshToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// randomString возвращает случайную строку в base64url без выравнивания.
+func randomString() (string, error) {
+	buf := make([]byte, refreshTokenLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("%w: %w", errFailedGenerateToken, err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,77 @@
+package token_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/based-chat/auth/internal/keys"
+	"github.com/based-chat/auth/internal/model"
+	"github.com/based-chat/auth/internal/token"
+)
+
+func generate(t *testing.T) *keys.Key {
+	t.Helper()
+
+	key, err := keys.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	return key
+}
+
+// newManager создаёт Manager, подписывающий ключом active и проверяющий ещё и ключами retired.
+func newManager(t *testing.T, active *keys.Key, retired ...*keys.Key) *token.Manager {
+	t.Helper()
+
+	set, err := keys.NewSet(active, retired...)
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+
+	return token.NewManager("auth", "based-chat", time.Minute, time.Hour, set)
+}
+
+func TestParseAccessTokenAfterRotation(t *testing.T) {
+	previous := generate(t)
+	next := generate(t)
+
+	// Выведенный из оборота ключ загружается без закрытой части.
+	retired, err := keys.NewKey(previous.Public)
+	if err != nil {
+		t.Fatalf("NewKey() error = %v", err)
+	}
+
+	raw, _, err := newManager(t, previous).IssueAccessToken(&model.User{ID: 42, Role: model.RoleAdmin})
+	if err != nil {
+		t.Fatalf("IssueAccessToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		manager *token.Manager
+		wantErr error
+	}{
+		{name: "signing key is active", manager: newManager(t, previous)},
+		{name: "signing key is retired", manager: newManager(t, next, retired)},
+		{name: "signing key is dropped", manager: newManager(t, next), wantErr: token.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.manager.ParseAccessToken(raw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseAccessToken() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if id, _ := claims.UserID(); id != 42 || claims.Role != model.RoleAdmin {
+				t.Errorf("ParseAccessToken() claims = (%d, %q), want (42, %q)", id, claims.Role, model.RoleAdmin)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// instrumentationName — имя, под которым интерцептор создаёт спаны.
+const instrumentationName = "github.com/based-chat/auth/internal/tracing"
+
+// serverErrorCodes — коды ответа, при которых спан сервера помечается ошибкой.
+// Остальные коды означают ошибку клиента, а не сбой обработчика.
+var serverErrorCodes = map[codes.Code]struct{}{
+	codes.Unknown:          {},
+	codes.DeadlineExceeded: {},
+	codes.Unimplemented:    {},
+	codes.Internal:         {},
+	codes.Unavailable:      {},
+	codes.DataLoss:         {},
+}
+
+// Interceptor открывает спан на каждый вызов методов выбранных gRPC-сервисов, продолжая
+// трассировку, контекст которой пришёл в метаданных запроса.
+type Interceptor struct {
+	tracer   trace.Tracer
+	services map[string]struct{}
+}
+
+// NewInterceptor создаёт интерцептор для сервисов services, заданных полными именами
+// вроде "user.v1.UserV1". Вызовы остальных сервисов не трассируются.
+func NewInterceptor(services ...string) *Interceptor {
+	i := &Interceptor{
+		tracer:   otel.Tracer(instrumentationName),
+		services: make(map[string]struct{}, len(services)),
+	}
+
+	for _, service := range services {
+		i.services[service] = struct{}{}
+	}
+
+	return i
+}
+
+// Unary трассирует unary-вызов.
+func (i *Interceptor) Unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, span, ok := i.start(ctx, info.FullMethod)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	resp, err := handler(ctx, req)
+	end(span, err)
+
+	return resp, err
+}
+
+// Stream трассирует потоковый вызов целиком, от открытия до закрытия потока.
+func (i *Interceptor) Stream(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, span, ok := i.start(ss.Context(), info.FullMethod)
+	if !ok {
+		return handler(srv, ss)
+	}
+
+	err := handler(srv, &tracedStream{ServerStream: ss, ctx: ctx})
+	end(span, err)
+
+	return err
+}
+
+// start открывает спан сервера для fullMethod вида "/user.v1.UserV1/Get".
+// Если сервис не трассируется, ok равен false.
+func (i *Interceptor) start(ctx context.Context, fullMethod string) (context.Context, trace.Span, bool) {
+	name := strings.TrimPrefix(fullMethod, "/")
+
+	service, method, _ := strings.Cut(name, "/")
+	if _, ok := i.services[service]; !ok {
+		return ctx, nil, false
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	ctx, span := i.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(method),
+		),
+	)
+
+	return ctx, span, true
+}
+
+// end записывает код ответа в span и закрывает его.
+func end(span trace.Span, err error) {
+	st := status.Convert(err)
+
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+
+	if _, ok := serverErrorCodes[st.Code()]; ok {
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+
+	span.End()
+}
+
+// tracedStream подменяет контекст потока контекстом со спаном.
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context возвращает контекст со спаном вызова.
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+var _ propagation.TextMapCarrier = metadataCarrier(nil)
+
+// metadataCarrier читает и записывает контекст трассировки в метаданные gRPC.
+type metadataCarrier metadata.MD
+
+// Get возвращает первое значение ключа key.
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// Set заменяет значения ключа key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys возвращает все ключи метаданных.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,108 @@
+// Package tracing configures OpenTelemetry tracing and instruments gRPC handlers.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/based-chat/auth/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// serviceName — имя сервиса в трассировках.
+const serviceName = "auth"
+
+var (
+	errFailedCreateExporter = errors.New("failed to create trace exporter")
+	errFailedOpenTraceFile  = errors.New("failed to open trace file")
+)
+
+// Provider владеет экспортёром трассировок и отправляет накопленные спаны при остановке.
+type Provider struct {
+	provider *sdktrace.TracerProvider
+	// file — файл файлового экспортёра; nil для остальных.
+	file *os.File
+}
+
+// NewProvider создаёт экспортёр по конфигурации и делает его глобальным поставщиком трассировок.
+// Контекст трассировки передаётся в формате W3C Trace Context даже при выключенной трассировке,
+// чтобы не разрывать цепочку вызовов между сервисами.
+func NewProvider(ctx context.Context, cfg config.TracingConfig) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		p        Provider
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+
+	switch cfg.Exporter() {
+	case config.TracingExporterNone:
+		return &p, nil
+	case config.TracingExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint())}
+		if cfg.OTLPInsecure() {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err = otlptracegrpc.New(ctx, options...)
+	case config.TracingExporterStdout:
+		exporter, err = newWriterExporter(os.Stdout)
+	case config.TracingExporterFile:
+		p.file, err = os.OpenFile(cfg.File(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errFailedOpenTraceFile, err)
+		}
+
+		exporter, err = newWriterExporter(p.file)
+	}
+
+	if err != nil {
+		if p.file != nil {
+			_ = p.file.Close()
+		}
+
+		return nil, fmt.Errorf("%w: %w", errFailedCreateExporter, err)
+	}
+
+	p.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio()))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(p.provider)
+
+	return &p, nil
+}
+
+// Shutdown отправляет накопленные спаны и закрывает экспортёр.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.provider == nil {
+		return nil
+	}
+
+	err := p.provider.Shutdown(ctx)
+
+	if p.file != nil {
+		err = errors.Join(err, p.file.Close())
+	}
+
+	return err
+}
+
+// newWriterExporter создаёт экспортёр, пишущий спаны в w построчно в формате JSON.
+func newWriterExporter(w io.Writer) (sdktrace.SpanExporter, error) {
+	return stdouttrace.New(stdouttrace.WithWriter(w))
+}
@@ -0,0 +1,281 @@
+// Package validate checks protobuf messages against the validate.v1 field rules.
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	validateV1 "github.com/based-chat/auth/pkg/validate/v1"
+)
+
+// stringValueName — полное имя обёртки google.protobuf.StringValue.
+var stringValueName = (&wrapperspb.StringValue{}).ProtoReflect().Descriptor().FullName()
+
+// Violation — нарушение правила одним полем.
+type Violation struct {
+	// Field — путь к полю, например "filter.roles[1]".
+	Field string
+	// Description — описание нарушения.
+	Description string
+}
+
+// String возвращает нарушение в виде "поле: описание".
+func (v Violation) String() string {
+	return v.Field + ": " + v.Description
+}
+
+// Message проверяет msg и вложенные в него сообщения по правилам полей
+// и возвращает все найденные нарушения; nil — сообщение корректно.
+func Message(msg proto.Message) []Violation {
+	var v validator
+
+	v.message(msg.ProtoReflect(), "")
+
+	return v.violations
+}
+
+// validator накапливает нарушения при обходе сообщения.
+type validator struct {
+	violations []Violation
+}
+
+// add записывает нарушение поля path.
+func (v *validator) add(path, format string, args ...any) {
+	v.violations = append(v.violations, Violation{Field: path, Description: fmt.Sprintf(format, args...)})
+}
+
+// message проверяет поля msg; prefix — путь к msg от корня.
+func (v *validator) message(msg protoreflect.Message, prefix string) {
+	fields := msg.Descriptor().Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + fd.TextName()
+
+		if rules := fieldRules(fd); rules != nil {
+			v.field(msg, fd, rules, path)
+		}
+
+		if fd.Message() == nil || fd.IsMap() || !msg.Has(fd) {
+			continue
+		}
+
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				v.message(list.Get(j).Message(), path+"["+strconv.Itoa(j)+"].")
+			}
+
+			continue
+		}
+
+		v.message(msg.Get(fd).Message(), path+".")
+	}
+}
+
+// field проверяет значение поля fd сообщения msg по правилам rules.
+func (v *validator) field(
+	msg protoreflect.Message,
+	fd protoreflect.FieldDescriptor,
+	rules *validateV1.FieldRules,
+	path string,
+) {
+	if !msg.Has(fd) {
+		if rules.GetRequired() {
+			v.add(path, "value is required")
+			return
+		}
+
+		// Незаданное сообщение, в том числе обёртка, не проверяется.
+		if fd.Message() != nil {
+			return
+		}
+	}
+
+	if fd.IsList() {
+		v.list(msg.Get(fd).List(), fd, rules.GetRepeated(), path)
+		return
+	}
+
+	v.value(msg.Get(fd), fd, rules, path)
+}
+
+// list проверяет число элементов списка и каждый элемент.
+func (v *validator) list(
+	list protoreflect.List,
+	fd protoreflect.FieldDescriptor,
+	rules *validateV1.RepeatedRules,
+	path string,
+) {
+	if rules == nil {
+		return
+	}
+
+	count := uint64(list.Len())
+
+	if rules.MinItems != nil && count < rules.GetMinItems() {
+		v.add(path, "value must contain at least %d item(s)", rules.GetMinItems())
+	}
+
+	if rules.MaxItems != nil && count > rules.GetMaxItems() {
+		v.add(path, "value must contain no more than %d item(s)", rules.GetMaxItems())
+	}
+
+	if items := rules.GetItems(); items != nil {
+		for i := 0; i < list.Len(); i++ {
+			v.value(list.Get(i), fd, items, path+"["+strconv.Itoa(i)+"]")
+		}
+	}
+}
+
+// value проверяет одиночное значение поля fd.
+func (v *validator) value(
+	value protoreflect.Value,
+	fd protoreflect.FieldDescriptor,
+	rules *validateV1.FieldRules,
+	path string,
+) {
+	switch {
+	case rules.GetString_() != nil:
+		if s, ok := stringValue(value, fd); ok {
+			v.string(s, rules.GetString_(), path)
+		}
+	case rules.GetInt32() != nil && fd.Kind() == protoreflect.Int32Kind:
+		v.int32(int32(value.Int()), rules.GetInt32(), path)
+	case rules.GetInt64() != nil && fd.Kind() == protoreflect.Int64Kind:
+		v.int64(value.Int(), rules.GetInt64(), path)
+	case rules.GetEnum() != nil && fd.Kind() == protoreflect.EnumKind:
+		v.enum(value.Enum(), fd.Enum(), rules.GetEnum(), path)
+	}
+}
+
+// string проверяет строку по правилам rules.
+func (v *validator) string(s string, rules *validateV1.StringRules, path string) {
+	length := uint64(utf8.RuneCountInString(s))
+
+	if rules.MinLen != nil && length < rules.GetMinLen() {
+		v.add(path, "value length must be at least %d characters", rules.GetMinLen())
+	}
+
+	if rules.MaxLen != nil && length > rules.GetMaxLen() {
+		v.add(path, "value length must be at most %d characters", rules.GetMaxLen())
+	}
+
+	if rules.GetEmail() && !isEmail(s) {
+		v.add(path, "value must be a valid email address")
+	}
+
+	if rules.GetStrongPassword() && !isStrongPassword(s) {
+		v.add(path, "value must contain a lower case letter, an upper case letter and a digit")
+	}
+}
+
+// int32 проверяет число по правилам rules.
+func (v *validator) int32(n int32, rules *validateV1.Int32Rules, path string) {
+	if rules.Gte != nil && n < rules.GetGte() {
+		v.add(path, "value must be greater than or equal to %d", rules.GetGte())
+	}
+
+	if rules.Lte != nil && n > rules.GetLte() {
+		v.add(path, "value must be less than or equal to %d", rules.GetLte())
+	}
+}
+
+// int64 проверяет число по правилам rules.
+func (v *validator) int64(n int64, rules *validateV1.Int64Rules, path string) {
+	if rules.Gt != nil && n <= rules.GetGt() {
+		v.add(path, "value must be greater than %d", rules.GetGt())
+	}
+}
+
+// enum проверяет значение перечисления ed по правилам rules.
+func (v *validator) enum(
+	n protoreflect.EnumNumber,
+	ed protoreflect.EnumDescriptor,
+	rules *validateV1.EnumRules,
+	path string,
+) {
+	if rules.GetDefinedOnly() && ed.Values().ByNumber(n) == nil {
+		v.add(path, "value must be one of the defined enum values")
+		return
+	}
+
+	for _, excluded := range rules.GetNotIn() {
+		if int32(n) == excluded {
+			v.add(path, "value must not be %s", enumName(n, ed))
+			return
+		}
+	}
+}
+
+// fieldRules возвращает правила поля fd или nil, если их нет.
+func fieldRules(fd protoreflect.FieldDescriptor) *validateV1.FieldRules {
+	opts := fd.Options()
+	if opts == nil || !proto.HasExtension(opts, validateV1.E_Field) {
+		return nil
+	}
+
+	rules, _ := proto.GetExtension(opts, validateV1.E_Field).(*validateV1.FieldRules)
+
+	return rules
+}
+
+// stringValue возвращает строку из поля типа string или обёртки google.protobuf.StringValue.
+func stringValue(value protoreflect.Value, fd protoreflect.FieldDescriptor) (string, bool) {
+	switch {
+	case fd.Kind() == protoreflect.StringKind:
+		return value.String(), true
+	case fd.Message() != nil && fd.Message().FullName() == stringValueName:
+		msg := value.Message()
+		return msg.Get(msg.Descriptor().Fields().ByName("value")).String(), true
+	}
+
+	return "", false
+}
+
+// enumName возвращает имя значения перечисления или его номер, если имени нет.
+func enumName(n protoreflect.EnumNumber, ed protoreflect.EnumDescriptor) string {
+	if value := ed.Values().ByNumber(n); value != nil {
+		return string(value.Name())
+	}
+
+	return strconv.Itoa(int(n))
+}
+
+// isEmail сообщает, является ли s адресом вида "user@example.com" без отображаемого имени.
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Name != "" || addr.Address != s {
+		return false
+	}
+
+	_, domain, _ := strings.Cut(s, "@")
+
+	return strings.Contains(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+// isStrongPassword сообщает, есть ли в s строчная и заглавная буквы и цифра.
+func isStrongPassword(s string) bool {
+	var lower, upper, digit bool
+
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+
+	return lower && upper && digit
+}
@@ -0,0 +1,134 @@
+package validate_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/based-chat/auth/internal/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	userV1 "github.com/based-chat/auth/pkg/user/v1"
+)
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  proto.Message
+		want []validate.Violation
+	}{
+		{
+			name: "valid create",
+			msg: &userV1.CreateRequest{
+				Name:     "Alice",
+				Email:    "alice@example.com",
+				Password: "Passw0rd",
+				Role:     userV1.UserRole_USER,
+			},
+		},
+		{
+			name: "empty create",
+			msg:  &userV1.CreateRequest{},
+			want: []validate.Violation{
+				{Field: "name", Description: "value is required"},
+				{Field: "email", Description: "value is required"},
+				{Field: "password", Description: "value is required"},
+			},
+		},
+		{
+			name: "invalid create fields",
+			msg: &userV1.CreateRequest{
+				Name:     strings.Repeat("я", 101),
+				Email:    "Alice <alice@example.com>",
+				Password: "password",
+				Role:     userV1.UserRole(42),
+			},
+			want: []validate.Violation{
+				{Field: "name", Description: "value length must be at most 100 characters"},
+				{Field: "email", Description: "value must be a valid email address"},
+				{
+					Field:       "password",
+					Description: "value must contain a lower case letter, an upper case letter and a digit",
+				},
+				{Field: "role", Description: "value must be one of the defined enum values"},
+			},
+		},
+		{
+			name: "short password",
+			msg:  &userV1.CreateRequest{Name: "Alice", Email: "alice@example.com", Password: "Pa1"},
+			want: []validate.Violation{
+				{Field: "password", Description: "value length must be at least 8 characters"},
+			},
+		},
+		{
+			name: "email without top-level domain",
+			msg:  &userV1.CreateRequest{Name: "Alice", Email: "alice@localhost", Password: "Passw0rd"},
+			want: []validate.Violation{
+				{Field: "email", Description: "value must be a valid email address"},
+			},
+		},
+		{
+			name: "zero id",
+			msg:  &userV1.GetRequest{},
+			want: []validate.Violation{
+				{Field: "id", Description: "value must be greater than 0"},
+			},
+		},
+		{
+			name: "unset wrappers are skipped",
+			msg:  &userV1.UpdateRequest{Id: 1},
+		},
+		{
+			name: "invalid wrappers",
+			msg: &userV1.UpdateRequest{
+				Id:    1,
+				Name:  wrapperspb.String(""),
+				Email: wrapperspb.String("alice"),
+			},
+			want: []validate.Violation{
+				{Field: "name", Description: "value length must be at least 1 characters"},
+				{Field: "email", Description: "value must be a valid email address"},
+			},
+		},
+		{
+			name: "nested filter",
+			msg: &userV1.ListRequest{
+				PageSize: -1,
+				Filter: &userV1.ListFilter{
+					Roles:      []userV1.UserRole{userV1.UserRole_ADMIN, userV1.UserRole_UNSPECIFIED, 42},
+					NamePrefix: strings.Repeat("a", 101),
+				},
+			},
+			want: []validate.Violation{
+				{Field: "page_size", Description: "value must be greater than or equal to 0"},
+				{Field: "filter.roles[1]", Description: "value must not be UNSPECIFIED"},
+				{Field: "filter.roles[2]", Description: "value must be one of the defined enum values"},
+				{Field: "filter.name_prefix", Description: "value length must be at most 100 characters"},
+			},
+		},
+		{
+			name: "empty required list",
+			msg:  &userV1.GetManyRequest{},
+			want: []validate.Violation{
+				{Field: "ids", Description: "value is required"},
+			},
+		},
+		{
+			name: "invalid list items",
+			msg:  &userV1.GetManyRequest{Ids: []int64{1, 0, -1}},
+			want: []validate.Violation{
+				{Field: "ids[1]", Description: "value must be greater than 0"},
+				{Field: "ids[2]", Description: "value must be greater than 0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate.Message(tt.msg); !slices.Equal(got, tt.want) {
+				t.Errorf("Message() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@
 package user_v1
 
 import (
+	_ "github.com/based-chat/auth/pkg/validate/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -23,12 +24,69 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SortField int32
+
+const (
+	SortField_SORT_FIELD_UNSPECIFIED SortField = 0
+	SortField_SORT_FIELD_ID          SortField = 1
+	SortField_SORT_FIELD_CREATED_AT  SortField = 2
+	SortField_SORT_FIELD_NAME        SortField = 3
+	SortField_SORT_FIELD_EMAIL       SortField = 4
+)
+
+// Enum value maps for SortField.
+var (
+	SortField_name = map[int32]string{
+		0: "SORT_FIELD_UNSPECIFIED",
+		1: "SORT_FIELD_ID",
+		2: "SORT_FIELD_CREATED_AT",
+		3: "SORT_FIELD_NAME",
+		4: "SORT_FIELD_EMAIL",
+	}
+	SortField_value = map[string]int32{
+		"SORT_FIELD_UNSPECIFIED": 0,
+		"SORT_FIELD_ID":          1,
+		"SORT_FIELD_CREATED_AT":  2,
+		"SORT_FIELD_NAME":        3,
+		"SORT_FIELD_EMAIL":       4,
+	}
+)
+
+func (x SortField) Enum() *SortField {
+	p := new(SortField)
+	*p = x
+	return p
+}
+
+func (x SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_user_proto_enumTypes[0].Descriptor()
+}
+
+func (SortField) Type() protoreflect.EnumType {
+	return &file_user_proto_enumTypes[0]
+}
+
+func (x SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortField.Descriptor instead.
+func (SortField) EnumDescriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{0}
+}
+
 type UserRole int32
 
 const (
 	UserRole_UNSPECIFIED UserRole = 0
 	UserRole_ADMIN       UserRole = 1
 	UserRole_USER        UserRole = 2
+	UserRole_MODERATOR   UserRole = 3
+	UserRole_SUPPORT     UserRole = 4
 )
 
 // Enum value maps for UserRole.
@@ -37,11 +95,15 @@ var (
 		0: "UNSPECIFIED",
 		1: "ADMIN",
 		2: "USER",
+		3: "MODERATOR",
+		4: "SUPPORT",
 	}
 	UserRole_value = map[string]int32{
 		"UNSPECIFIED": 0,
 		"ADMIN":       1,
 		"USER":        2,
+		"MODERATOR":   3,
+		"SUPPORT":     4,
 	}
 )
 
@@ -56,11 +118,11 @@ func (x UserRole) String() string {
 }
 
 func (UserRole) Descriptor() protoreflect.EnumDescriptor {
-	return file_user_proto_enumTypes[0].Descriptor()
+	return file_user_proto_enumTypes[1].Descriptor()
 }
 
 func (UserRole) Type() protoreflect.EnumType {
-	return &file_user_proto_enumTypes[0]
+	return &file_user_proto_enumTypes[1]
 }
 
 func (x UserRole) Number() protoreflect.EnumNumber {
@@ -69,7 +131,7 @@ func (x UserRole) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use UserRole.Descriptor instead.
 func (UserRole) EnumDescriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{0}
+	return file_user_proto_rawDescGZIP(), []int{1}
 }
 
 type CreateRequest struct {
@@ -313,10 +375,12 @@ func (x *GetResponse) GetUpdatedAt() *timestamppb.Timestamp {
 }
 
 type UpdateRequest struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Id            int64                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Fields that are not set are left unchanged.
 	Name          *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Email         *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role          UserRole                `protobuf:"varint,4,opt,name=role,proto3,enum=user.v1.UserRole" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -372,6 +436,13 @@ func (x *UpdateRequest) GetEmail() *wrapperspb.StringValue {
 	return nil
 }
 
+func (x *UpdateRequest) GetRole() UserRole {
+	if x != nil {
+		return x.Role
+	}
+	return UserRole_UNSPECIFIED
+}
+
 type DeleteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -460,22 +531,332 @@ func (x *DeleteResponse) GetDeleted() bool {
 	return false
 }
 
+type ListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of users to return; 0 means the server default.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from the previous response. Filter and ordering must not change between pages.
+	PageToken     string      `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter        *ListFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	SortField     SortField   `protobuf:"varint,4,opt,name=sort_field,json=sortField,proto3,enum=user.v1.SortField" json:"sort_field,omitempty"`
+	Descending    bool        `protobuf:"varint,5,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRequest) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListRequest) GetSortField() SortField {
+	if x != nil {
+		return x.SortField
+	}
+	return SortField_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *ListRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type ListFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Roles []UserRole             `protobuf:"varint,1,rep,packed,name=roles,proto3,enum=user.v1.UserRole" json:"roles,omitempty"`
+	// Inclusive lower bound of the creation time.
+	CreatedAfter *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	// Exclusive upper bound of the creation time.
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Case-insensitive prefix of the email.
+	EmailPrefix string `protobuf:"bytes,4,opt,name=email_prefix,json=emailPrefix,proto3" json:"email_prefix,omitempty"`
+	// Case-insensitive prefix of the name.
+	NamePrefix    string `protobuf:"bytes,5,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilter) Reset() {
+	*x = ListFilter{}
+	mi := &file_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilter) ProtoMessage() {}
+
+func (x *ListFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilter.ProtoReflect.Descriptor instead.
+func (*ListFilter) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListFilter) GetRoles() []UserRole {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *ListFilter) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListFilter) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListFilter) GetEmailPrefix() string {
+	if x != nil {
+		return x.EmailPrefix
+	}
+	return ""
+}
+
+func (x *ListFilter) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+type ListResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*GetResponse         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Empty when there are no more pages.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListResponse) GetUsers() []*GetResponse {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetManyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The upper bound on the number of IDs is set by the server configuration.
+	Ids           []int64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetManyRequest) Reset() {
+	*x = GetManyRequest{}
+	mi := &file_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetManyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManyRequest) ProtoMessage() {}
+
+func (x *GetManyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManyRequest.ProtoReflect.Descriptor instead.
+func (*GetManyRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetManyRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type GetManyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Found users in the order of their first occurrence in the request.
+	Users         []*GetResponse `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	MissingIds    []int64        `protobuf:"varint,2,rep,packed,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetManyResponse) Reset() {
+	*x = GetManyResponse{}
+	mi := &file_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetManyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManyResponse) ProtoMessage() {}
+
+func (x *GetManyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManyResponse.ProtoReflect.Descriptor instead.
+func (*GetManyResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetManyResponse) GetUsers() []*GetResponse {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *GetManyResponse) GetMissingIds() []int64 {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
 var File_user_proto protoreflect.FileDescriptor
 
 const file_user_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"user.proto\x12\auser.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\"|\n" +
-	"\rCreateRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x03 \x01(\tR\bpassword\x12%\n" +
-	"\x04role\x18\x04 \x01(\x0e2\x11.user.v1.UserRoleR\x04role\" \n" +
+	"user.proto\x12\auser.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x0evalidate.proto\"\xb2\x01\n" +
+	"\rCreateRequest\x12\x1e\n" +
+	"\x04name\x18\x01 \x01(\tB\n" +
+	"\x8a\xb5\x18\x06\b\x01\x12\x02\x10dR\x04name\x12#\n" +
+	"\x05email\x18\x02 \x01(\tB\r\x8a\xb5\x18\t\b\x01\x12\x05\x10\xfe\x01\x18\x01R\x05email\x12+\n" +
+	"\bpassword\x18\x03 \x01(\tB\x0f\x8a\xb5\x18\v\b\x01\x12\a\b\b\x10\x80\x01 \x01R\bpassword\x12/\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x11.user.v1.UserRoleB\b\x8a\xb5\x18\x04*\x02\b\x01R\x04role\" \n" +
 	"\x0eCreateResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\x1c\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"&\n" +
 	"\n" +
-	"GetRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\xe4\x01\n" +
+	"GetRequest\x12\x18\n" +
+	"\x02id\x18\x01 \x01(\x03B\b\x8a\xb5\x18\x04\"\x02\b\x00R\x02id\"\xe4\x01\n" +
 	"\vGetResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
@@ -484,24 +865,64 @@ const file_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x85\x01\n" +
-	"\rUpdateRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x120\n" +
-	"\x04name\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueR\x04name\x122\n" +
-	"\x05email\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\x05email\"\x1f\n" +
-	"\rDeleteRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"*\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd9\x01\n" +
+	"\rUpdateRequest\x12\x18\n" +
+	"\x02id\x18\x01 \x01(\x03B\b\x8a\xb5\x18\x04\"\x02\b\x00R\x02id\x12<\n" +
+	"\x04name\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueB\n" +
+	"\x8a\xb5\x18\x06\x12\x04\b\x01\x10dR\x04name\x12?\n" +
+	"\x05email\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueB\v\x8a\xb5\x18\a\x12\x05\x10\xfe\x01\x18\x01R\x05email\x12/\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x11.user.v1.UserRoleB\b\x8a\xb5\x18\x04*\x02\b\x01R\x04role\")\n" +
+	"\rDeleteRequest\x12\x18\n" +
+	"\x02id\x18\x01 \x01(\x03B\b\x8a\xb5\x18\x04\"\x02\b\x00R\x02id\"*\n" +
 	"\x0eDeleteResponse\x12\x18\n" +
-	"\adeleted\x18\x01 \x01(\bR\adeleted*0\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\xdd\x01\n" +
+	"\vListRequest\x12%\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\b\x8a\xb5\x18\x04\x1a\x02\b\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12+\n" +
+	"\x06filter\x18\x03 \x01(\v2\x13.user.v1.ListFilterR\x06filter\x12;\n" +
+	"\n" +
+	"sort_field\x18\x04 \x01(\x0e2\x12.user.v1.SortFieldB\b\x8a\xb5\x18\x04*\x02\b\x01R\tsortField\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x05 \x01(\bR\n" +
+	"descending\"\xa3\x02\n" +
+	"\n" +
+	"ListFilter\x128\n" +
+	"\x05roles\x18\x01 \x03(\x0e2\x11.user.v1.UserRoleB\x0f\x8a\xb5\x18\v2\t\x1a\a*\x05\b\x01\x12\x01\x00R\x05roles\x12?\n" +
+	"\rcreated_after\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12,\n" +
+	"\femail_prefix\x18\x04 \x01(\tB\t\x8a\xb5\x18\x05\x12\x03\x10\xfe\x01R\vemailPrefix\x12)\n" +
+	"\vname_prefix\x18\x05 \x01(\tB\b\x8a\xb5\x18\x04\x12\x02\x10dR\n" +
+	"namePrefix\"b\n" +
+	"\fListResponse\x12*\n" +
+	"\x05users\x18\x01 \x03(\v2\x14.user.v1.GetResponseR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"2\n" +
+	"\x0eGetManyRequest\x12 \n" +
+	"\x03ids\x18\x01 \x03(\x03B\x0e\x8a\xb5\x18\n" +
+	"\b\x012\x06\x1a\x04\"\x02\b\x00R\x03ids\"^\n" +
+	"\x0fGetManyResponse\x12*\n" +
+	"\x05users\x18\x01 \x03(\v2\x14.user.v1.GetResponseR\x05users\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\x03R\n" +
+	"missingIds*\x80\x01\n" +
+	"\tSortField\x12\x1a\n" +
+	"\x16SORT_FIELD_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSORT_FIELD_ID\x10\x01\x12\x19\n" +
+	"\x15SORT_FIELD_CREATED_AT\x10\x02\x12\x13\n" +
+	"\x0fSORT_FIELD_NAME\x10\x03\x12\x14\n" +
+	"\x10SORT_FIELD_EMAIL\x10\x04*L\n" +
 	"\bUserRole\x12\x0f\n" +
 	"\vUNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05ADMIN\x10\x01\x12\b\n" +
-	"\x04USER\x10\x022\xe8\x01\n" +
+	"\x04USER\x10\x02\x12\r\n" +
+	"\tMODERATOR\x10\x03\x12\v\n" +
+	"\aSUPPORT\x10\x042\xdb\x02\n" +
 	"\x06UserV1\x129\n" +
 	"\x06Create\x12\x16.user.v1.CreateRequest\x1a\x17.user.v1.CreateResponse\x120\n" +
 	"\x03Get\x12\x13.user.v1.GetRequest\x1a\x14.user.v1.GetResponse\x126\n" +
 	"\x06Update\x12\x16.user.v1.UpdateRequest\x1a\x14.user.v1.GetResponse\x129\n" +
-	"\x06Delete\x12\x16.user.v1.DeleteRequest\x1a\x17.user.v1.DeleteResponseB0Z.github.com/based-chat/auth/pkg/user/v1;user_v1b\x06proto3"
+	"\x06Delete\x12\x16.user.v1.DeleteRequest\x1a\x17.user.v1.DeleteResponse\x123\n" +
+	"\x04List\x12\x14.user.v1.ListRequest\x1a\x15.user.v1.ListResponse\x12<\n" +
+	"\aGetMany\x12\x17.user.v1.GetManyRequest\x1a\x18.user.v1.GetManyResponseB0Z.github.com/based-chat/auth/pkg/user/v1;user_v1b\x06proto3"
 
 var (
 	file_user_proto_rawDescOnce sync.Once
@@ -515,40 +936,58 @@ func file_user_proto_rawDescGZIP() []byte {
 	return file_user_proto_rawDescData
 }
 
-var file_user_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_user_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_user_proto_goTypes = []any{
-	(UserRole)(0),                  // 0: user.v1.UserRole
-	(*CreateRequest)(nil),          // 1: user.v1.CreateRequest
-	(*CreateResponse)(nil),         // 2: user.v1.CreateResponse
-	(*GetRequest)(nil),             // 3: user.v1.GetRequest
-	(*GetResponse)(nil),            // 4: user.v1.GetResponse
-	(*UpdateRequest)(nil),          // 5: user.v1.UpdateRequest
-	(*DeleteRequest)(nil),          // 6: user.v1.DeleteRequest
-	(*DeleteResponse)(nil),         // 7: user.v1.DeleteResponse
-	(*timestamppb.Timestamp)(nil),  // 8: google.protobuf.Timestamp
-	(*wrapperspb.StringValue)(nil), // 9: google.protobuf.StringValue
+	(SortField)(0),                 // 0: user.v1.SortField
+	(UserRole)(0),                  // 1: user.v1.UserRole
+	(*CreateRequest)(nil),          // 2: user.v1.CreateRequest
+	(*CreateResponse)(nil),         // 3: user.v1.CreateResponse
+	(*GetRequest)(nil),             // 4: user.v1.GetRequest
+	(*GetResponse)(nil),            // 5: user.v1.GetResponse
+	(*UpdateRequest)(nil),          // 6: user.v1.UpdateRequest
+	(*DeleteRequest)(nil),          // 7: user.v1.DeleteRequest
+	(*DeleteResponse)(nil),         // 8: user.v1.DeleteResponse
+	(*ListRequest)(nil),            // 9: user.v1.ListRequest
+	(*ListFilter)(nil),             // 10: user.v1.ListFilter
+	(*ListResponse)(nil),           // 11: user.v1.ListResponse
+	(*GetManyRequest)(nil),         // 12: user.v1.GetManyRequest
+	(*GetManyResponse)(nil),        // 13: user.v1.GetManyResponse
+	(*timestamppb.Timestamp)(nil),  // 14: google.protobuf.Timestamp
+	(*wrapperspb.StringValue)(nil), // 15: google.protobuf.StringValue
 }
 var file_user_proto_depIdxs = []int32{
-	0,  // 0: user.v1.CreateRequest.role:type_name -> user.v1.UserRole
-	0,  // 1: user.v1.GetResponse.role:type_name -> user.v1.UserRole
-	8,  // 2: user.v1.GetResponse.created_at:type_name -> google.protobuf.Timestamp
-	8,  // 3: user.v1.GetResponse.updated_at:type_name -> google.protobuf.Timestamp
-	9,  // 4: user.v1.UpdateRequest.name:type_name -> google.protobuf.StringValue
-	9,  // 5: user.v1.UpdateRequest.email:type_name -> google.protobuf.StringValue
-	1,  // 6: user.v1.UserV1.Create:input_type -> user.v1.CreateRequest
-	3,  // 7: user.v1.UserV1.Get:input_type -> user.v1.GetRequest
-	5,  // 8: user.v1.UserV1.Update:input_type -> user.v1.UpdateRequest
-	6,  // 9: user.v1.UserV1.Delete:input_type -> user.v1.DeleteRequest
-	2,  // 10: user.v1.UserV1.Create:output_type -> user.v1.CreateResponse
-	4,  // 11: user.v1.UserV1.Get:output_type -> user.v1.GetResponse
-	4,  // 12: user.v1.UserV1.Update:output_type -> user.v1.GetResponse
-	7,  // 13: user.v1.UserV1.Delete:output_type -> user.v1.DeleteResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	1,  // 0: user.v1.CreateRequest.role:type_name -> user.v1.UserRole
+	1,  // 1: user.v1.GetResponse.role:type_name -> user.v1.UserRole
+	14, // 2: user.v1.GetResponse.created_at:type_name -> google.protobuf.Timestamp
+	14, // 3: user.v1.GetResponse.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 4: user.v1.UpdateRequest.name:type_name -> google.protobuf.StringValue
+	15, // 5: user.v1.UpdateRequest.email:type_name -> google.protobuf.StringValue
+	1,  // 6: user.v1.UpdateRequest.role:type_name -> user.v1.UserRole
+	10, // 7: user.v1.ListRequest.filter:type_name -> user.v1.ListFilter
+	0,  // 8: user.v1.ListRequest.sort_field:type_name -> user.v1.SortField
+	1,  // 9: user.v1.ListFilter.roles:type_name -> user.v1.UserRole
+	14, // 10: user.v1.ListFilter.created_after:type_name -> google.protobuf.Timestamp
+	14, // 11: user.v1.ListFilter.created_before:type_name -> google.protobuf.Timestamp
+	5,  // 12: user.v1.ListResponse.users:type_name -> user.v1.GetResponse
+	5,  // 13: user.v1.GetManyResponse.users:type_name -> user.v1.GetResponse
+	2,  // 14: user.v1.UserV1.Create:input_type -> user.v1.CreateRequest
+	4,  // 15: user.v1.UserV1.Get:input_type -> user.v1.GetRequest
+	6,  // 16: user.v1.UserV1.Update:input_type -> user.v1.UpdateRequest
+	7,  // 17: user.v1.UserV1.Delete:input_type -> user.v1.DeleteRequest
+	9,  // 18: user.v1.UserV1.List:input_type -> user.v1.ListRequest
+	12, // 19: user.v1.UserV1.GetMany:input_type -> user.v1.GetManyRequest
+	3,  // 20: user.v1.UserV1.Create:output_type -> user.v1.CreateResponse
+	5,  // 21: user.v1.UserV1.Get:output_type -> user.v1.GetResponse
+	5,  // 22: user.v1.UserV1.Update:output_type -> user.v1.GetResponse
+	8,  // 23: user.v1.UserV1.Delete:output_type -> user.v1.DeleteResponse
+	11, // 24: user.v1.UserV1.List:output_type -> user.v1.ListResponse
+	13, // 25: user.v1.UserV1.GetMany:output_type -> user.v1.GetManyResponse
+	20, // [20:26] is the sub-list for method output_type
+	14, // [14:20] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -561,8 +1000,8 @@ func file_user_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},